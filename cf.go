@@ -29,19 +29,37 @@ If you want to compete, the best command is "cf race"
 
 Usage:
   cf config
-  cf submit [-f <file>] [<specifier>...]
-  cf list [<specifier>...]
+  cf submit [-f <file>] [--test] [--force] [--late] [--at <time>]
+            [<specifier>...]
+  cf list [--format <format>] [<specifier>...]
+  cf status [<specifier>...]
   cf parse [<specifier>...]
   cf gen [<alias>]
   cf test [<file>]
-  cf watch [all] [<specifier>...]
+  cf watch [all] [--json] [friends | --handles <handles>] [<specifier>...]
   cf open [<specifier>...]
   cf stand [<specifier>...]
   cf sid [<specifier>...]
   cf race [<specifier>...]
+  cf virtual [--at <time>] [<specifier>...]
   cf pull [ac] [<specifier>...]
   cf clone [ac] [<handle>]
   cf upgrade
+  cf problemset [unsolved] [--tags <tags>] [--rating <range>] [--solved <range>]
+                [--limit <n>]
+  cf queue (ls | cancel <job-id> | run)
+  cf langs [update]
+  cf dash [<specifier>...]
+  cf standings [friends | --handles <handles>] [--room <room>] [--page <n>]
+               [--limit <n>] [<specifier>...]
+  cf contests [upcoming | running | past] [gym] [--ics <file>] [--limit <n>]
+  cf rating changes [--handles <handles>] [--limit <n>] [<specifier>...]
+  cf rating [<handle>] [--limit <n>]
+  cf upsolve [--limit <n>]
+  cf hack <submission-id> [--input <file> | --gen <cmd> --checker <cmd>]
+          [--tries <n>] [<specifier>...]
+  cf history [--verdict <verdict>] [--since <date>] [--until <date>]
+             [<specifier>...]
 
 Options:
   -h --help            Show this screen.
@@ -57,6 +75,37 @@ Options:
                        want.
   <alias>              Template's alias. E.g. "cpp"
   ac                   The status of the submission is Accepted.
+  unsolved             Only the problems which have not been accepted by you.
+  --tags <tags>        Problems' tags separated by ";". E.g. "dp;greedy"
+  --rating <range>     Range of problems' rating. E.g. "1600-2000", "1900-"
+  --solved <range>     Range of the number of solvers. E.g. "1000-"
+  --test               Test all samples before submitting.
+  --force              Submit even if some samples failed.
+  --late               Submit even if the virtual contest has just ended.
+  --at <time>          Put the submission into the queue, or the start of a
+                       virtual contest. E.g. "start" (when the contest
+                       starts), "+5m", "21:35", "2019-10-01 21:35"
+  friends              You and your friends on Codeforces.
+  --handles <handles>  Handles separated by ";". E.g. "tourist;Petr"
+  --json               Print one line of JSON per change of a submission.
+  --verdict <verdict>  Part of the verdict. E.g. "accepted", "wrong answer"
+  --since <date>       From the date. E.g. "2019-10-01"
+  --until <date>       Until the date (inclusive). E.g. "2019-10-31"
+  --room <room>        Only the participants of the room.
+  --format <format>    Output format, one of "table", "json", "csv" and "tsv".
+  --ics <file>         Export all upcoming contests to an iCalendar file.
+  --input <file>       The test of a hack, sent as a manual test.
+  --gen <cmd>          Command of a generator, which prints a test with the
+                       seed given as the last argument. Its source, e.g.
+                       "gen.py" of "python3 gen.py" or "gen.cpp" of "./gen",
+                       is sent with the seed of the failing test.
+  --checker <cmd>      Command of a checker, which gets the path of the input
+                       and the output, and exits with non-zero if the output
+                       is wrong.
+  --tries <n>          The number of tests to generate [default: 100]
+  --page <n>           The page to show [default: 1]
+  --limit <n>          The maximum number of problems or rows, 0 means no
+                       limit [default: 20]
 
 Examples:
   cf config            Configure the cf-tool.
@@ -67,8 +116,27 @@ Examples:
   cf submit -f a.cpp 100 a
   cf submit contest 100 a
   cf submit gym 100001 a
+                       A gym or group contest which has ended takes practice
+                       submissions on its own page if it's open for practice.
+                       There is no problemset to fall back to.
+  cf submit problemset 1136 a
+                       Submit to the problemset. A contest which does not
+                       accept submissions any more falls back to it.
+  cf submit https://codeforces.com/problemset/problem/1136/A
+  cf submit -f a.cpp   Local headers included by a.cpp (#include "lib.hpp")
+                       are inlined before submitting. The bundled code is
+                       saved to "./bundle/a.cpp".
+  cf submit --test     Test all samples first and refuse to submit if any of
+                       them failed. Run "cf config" to always do it.
   cf list              List all problems' stats of a contest.
   cf list 1119
+  cf list --format json
+                       List all problems' stats of current contest in JSON,
+                       with the limits and IO parsed. "csv" and "tsv" are
+                       also supported.
+  cf status            Show what's left to do in current contest: whether each
+                       problem is parsed, its sources, the last result of
+                       "cf test" and the verdict of the last submission.
   cf parse 100         Fetch all problems' samples of contest 100 into
                        "{cf}/{contest}/100/<problem-id>".
   cf parse gym 100001a
@@ -78,6 +146,7 @@ Examples:
                        Fetch all problems' samples of gym 100001 into
                        "{cf}/{gym}/100001".
   cf parse             Fetch samples of current problem into current path.
+                       Samples edited or added locally are never overwritten.
   cf gen               Generate a code from default template.
   cf gen cpp           Generate a code from the template whose alias is "cpp"
                        into current path.
@@ -86,7 +155,20 @@ Examples:
                        create two files "inK.txt" and "ansK.txt" where K is
                        a string with 0~9.
   cf watch             Watch the first 10 submissions of current contest.
+                       Then show the compilation error of the latest one, or
+                       the test it failed on once the tests are public, which
+                       could be saved as a sample.
   cf watch all         Watch all submissions of current contest.
+  cf watch --json      Print one line of JSON whenever a submission changes,
+                       e.g. {"id":1,"problem":"A - X","verdict":"Accepted",
+                       "test":0,"time":15,"memory":2048,...}. It's the default
+                       when the output is not a terminal.
+  cf watch friends 1234
+                       Watch the submissions of you and your friends in
+                       contest 1234.
+  cf watch --handles "alice;bob"
+                       Watch the submissions of alice and bob in current
+                       contest.
   cf open 1136a        Use default web browser to open the page of contest
                        1136, problem a.
   cf open gym 100136   Use default web browser to open the page of gym
@@ -108,15 +190,86 @@ Examples:
                        path.
   cf clone xalanq      Clone all codes of xalanq.
   cf upgrade           Upgrade the "cf" to the latest version from GitHub.
+  cf submit --at start Submit the code as soon as the contest starts. The
+                       submission waits in the queue until "cf queue run".
+  cf queue ls          List all submissions in the queue.
+  cf queue cancel 2    Remove the submission #2 from the queue.
+  cf queue run         Submit all submissions in the queue on time. The time
+                       is based on the clock of Codeforces.
+  cf langs             List all languages with their ids and extensions.
+  cf langs update      Fetch the latest languages from the submit page.
+  cf history 1136      List all submissions of contest 1136 made by cf.
+  cf history --verdict wrong --since 2019-10-01
+                       List all "Wrong answer" submissions since 2019-10-01.
+  cf dash 1234         Open a full-screen dashboard of contest 1234 with the
+                       countdown, problems, your submissions and rank. Select
+                       a problem by j/k, then press p/t/s/o to parse, test,
+                       submit or open it. Press q to quit.
+  cf standings         Show the standings of current contest in the terminal,
+                       with your own row highlighted.
+  cf standings friends --page 2
+                       Show the second page of the standings of you and
+                       your friends.
+  cf contests          List the upcoming and running contests with your
+                       registration status.
+  cf contests past gym List the recent contests in gym.
+  cf contests --ics cf.ics
+                       Export the upcoming contests to "cf.ics", which could
+                       be imported by calendar apps.
+  cf rating            Draw the rating chart of yours and list the rating
+                       changes of the last 20 contests.
+  cf rating tourist    Draw the rating chart of tourist.
+  cf rating changes 1234 --handles "alice;bob"
+                       Show the rating changes of you, alice and bob in
+                       contest 1234 after the system testing.
+  cf virtual 1234      Register a virtual participation of contest 1234 which
+                       starts at the next minute, then race it. Submitting
+                       after the end of the virtual contest is refused.
+  cf virtual 1234 --at 21:00
+  cf problemset unsolved --tags "dp;greedy" --rating 1600-2000
+                       List problems of the problemset which you have not
+                       solved yet. Then choose one to parse its samples.
+  cf hack 52531875 --input test.txt
+                       Pull the code of submission 52531875 of current
+                       contest into "./hack/52531875", then hack it with
+                       the test in "test.txt".
+  cf hack 52531875 1136 --gen "python3 gen.py" --checker ./check
+                       Run the code against 100 tests of the generator, and
+                       hack it by the generator with the seed of the first
+                       test the checker rejects.
+  cf upsolve           List problems of the contests you took part in which
+                       you have not solved yet, easiest first. Then choose
+                       one to parse its samples. Accepted submissions made
+                       by cf drop out of the list at once.
 
 File:
   cf will save some data in some files:
 
   "~/.cf/config"        Configuration file, including templates, etc.
   "~/.cf/session"       Session file, including cookies, handle, password, etc.
+  "~/.cf/queue"         Queue file, including the scheduled submissions.
+  "~/.cf/history"       History file, including all submissions made by cf.
+  "~/.cf/virtual"       Virtual contests registered by "cf virtual".
+  "~/.cf/upsolve"       Contests and solved problems tracked by "cf upsolve".
+  "~/.cf/hacks"         Hacks made by "cf hack" with their tests and verdicts.
+  "~/.cf/langs"         Languages fetched by "cf langs update". You could set
+                        the extension of a language in "ext_overrides".
 
   "~" is the home directory of current user in your system.
 
+Verdict hooks:
+  Run "cf config" to set commands which run when a submission gets its final
+  verdict (e.g. in "cf submit" or "cf watch"), and to show a desktop
+  notification (notify-send or osascript). The verdict is passed to each
+  command by environment variables:
+
+  CF_SUBMISSION_ID, CF_PROBLEM_TYPE, CF_CONTEST_ID, CF_GROUP_ID, CF_HANDLE,
+  CF_PROBLEM, CF_LANG, CF_VERDICT (e.g. "Wrong answer on test 7"),
+  CF_VERDICT_TYPE (e.g. "WRONG_ANSWER"), CF_TEST (the failed test), CF_PASSED,
+  CF_POINTS, CF_TIME (ms), CF_MEMORY (bytes)
+
+  and as a JSON object on standard input.
+
 Template:
   You can insert some placeholders into your template code. When generate a code
   from the template, cf will replace all placeholders by following rules:
@@ -156,10 +309,14 @@ Script in template:
 	clnPath, _ := homedir.Expand(sessionPath)
 	config.Init(cfgPath)
 	client.Init(clnPath, config.Instance.Host, config.Instance.Proxy)
+	client.Instance.SetVerdictHooks(config.Instance.VerdictHooks, config.Instance.Notify)
 
 	err := cmd.Eval(opts)
 	if err != nil {
 		color.Red(err.Error())
 	}
 	color.Unset()
+	if err != nil {
+		os.Exit(1)
+	}
 }
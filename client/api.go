@@ -0,0 +1,174 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// APIProblem problem object of codeforces api
+type APIProblem struct {
+	ContestID int      `json:"contestId"`
+	Index     string   `json:"index"`
+	Name      string   `json:"name"`
+	Points    float64  `json:"points"`
+	Rating    int      `json:"rating"`
+	Tags      []string `json:"tags"`
+}
+
+// ID problem id, e.g. "1136A"
+func (p *APIProblem) ID() string {
+	return fmt.Sprintf("%v%v", p.ContestID, p.Index)
+}
+
+// APIParty party object of codeforces api
+type APIParty struct {
+	Members []struct {
+		Handle string `json:"handle"`
+	} `json:"members"`
+	TeamName        string `json:"teamName"`
+	ParticipantType string `json:"participantType"`
+}
+
+// Name team name or handles of members
+func (p *APIParty) Name() string {
+	if p.TeamName != "" {
+		return p.TeamName
+	}
+	handles := []string{}
+	for _, m := range p.Members {
+		handles = append(handles, m.Handle)
+	}
+	return strings.Join(handles, ", ")
+}
+
+// Has whether handle is a member of the party
+func (p *APIParty) Has(handle string) bool {
+	for _, m := range p.Members {
+		if strings.EqualFold(m.Handle, handle) {
+			return true
+		}
+	}
+	return false
+}
+
+// APISubmission submission object of codeforces api
+type APISubmission struct {
+	ID                  int64      `json:"id"`
+	ContestID           int        `json:"contestId"`
+	CreationTimeSeconds int64      `json:"creationTimeSeconds"`
+	Problem             APIProblem `json:"problem"`
+	Author              APIParty   `json:"author"`
+	ProgrammingLanguage string     `json:"programmingLanguage"`
+	Verdict             string     `json:"verdict"`
+	Testset             string     `json:"testset"`
+	PassedTestCount     int        `json:"passedTestCount"`
+	TimeConsumedMillis  int64      `json:"timeConsumedMillis"`
+	MemoryConsumedBytes int64      `json:"memoryConsumedBytes"`
+	Points              float64    `json:"points"`
+}
+
+// APIContest contest object of codeforces api
+type APIContest struct {
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Phase               string `json:"phase"`
+	Frozen              bool   `json:"frozen"`
+	DurationSeconds     int64  `json:"durationSeconds"`
+	StartTimeSeconds    int64  `json:"startTimeSeconds"`
+	RelativeTimeSeconds int64  `json:"relativeTimeSeconds"`
+}
+
+// APIProblemResult problem result object of codeforces api
+type APIProblemResult struct {
+	Points                    float64 `json:"points"`
+	Penalty                   int     `json:"penalty"`
+	RejectedAttemptCount      int     `json:"rejectedAttemptCount"`
+	Type                      string  `json:"type"`
+	BestSubmissionTimeSeconds int64   `json:"bestSubmissionTimeSeconds"`
+}
+
+// APIRanklistRow ranklist row object of codeforces api
+type APIRanklistRow struct {
+	Party                 APIParty           `json:"party"`
+	Rank                  int                `json:"rank"`
+	Points                float64            `json:"points"`
+	Penalty               int                `json:"penalty"`
+	SuccessfulHackCount   int                `json:"successfulHackCount"`
+	UnsuccessfulHackCount int                `json:"unsuccessfulHackCount"`
+	ProblemResults        []APIProblemResult `json:"problemResults"`
+}
+
+// APIStandings result of contest.standings
+type APIStandings struct {
+	Contest  APIContest       `json:"contest"`
+	Problems []APIProblem     `json:"problems"`
+	Rows     []APIRanklistRow `json:"rows"`
+}
+
+type apiResponse struct {
+	Status  string          `json:"status"`
+	Comment string          `json:"comment"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// apiInterval codeforces allows one call of the api per 2 seconds
+const apiInterval = 2 * time.Second
+
+// throttle block until the api could be called again
+func (c *Client) throttle() {
+	c.apiMutex.Lock()
+	defer c.apiMutex.Unlock()
+	if wait := apiInterval - time.Since(c.apiLast); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.apiLast = time.Now()
+}
+
+// api call method of codeforces api and decode the result into v. Calls are
+// kept apart by apiInterval
+func (c *Client) api(method string, query url.Values, v interface{}) (err error) {
+	c.throttle()
+	URL := fmt.Sprintf("%v/api/%v?%v", c.host, method, query.Encode())
+	resp, err := c.client.Get(URL)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	var data apiResponse
+	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return
+	}
+	if data.Status != "OK" {
+		if data.Comment != "" {
+			return errors.New(data.Comment)
+		}
+		return fmt.Errorf("Cannot call %v", method)
+	}
+	return json.Unmarshal(data.Result, v)
+}
+
+// UserStatus all submissions of handle
+func (c *Client) UserStatus(handle string) (submissions []APISubmission, err error) {
+	err = c.api("user.status", url.Values{"handle": {handle}}, &submissions)
+	return
+}
+
+// ContestStandings standings of the contest. query is the other parameters
+// of contest.standings, e.g. "handles" and "from"
+func (c *Client) ContestStandings(info Info, query url.Values) (standings APIStandings, err error) {
+	if info.ContestID == "" {
+		_, err = info.errorContest()
+		return
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("contestId", info.ContestID)
+	err = c.api("contest.standings", query, &standings)
+	return
+}
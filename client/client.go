@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/xalanq/cf-tool/cookiejar"
@@ -14,17 +16,23 @@ import (
 
 // Client codeforces client
 type Client struct {
-	Jar            *cookiejar.Jar `json:"cookies"`
-	Handle         string         `json:"handle"`
-	HandleOrEmail  string         `json:"handle_or_email"`
-	Password       string         `json:"password"`
-	Ftaa           string         `json:"ftaa"`
-	Bfaa           string         `json:"bfaa"`
-	LastSubmission *Info          `json:"last_submission"`
+	Jar            *cookiejar.Jar               `json:"cookies"`
+	Handle         string                       `json:"handle"`
+	HandleOrEmail  string                       `json:"handle_or_email"`
+	Password       string                       `json:"password"`
+	Ftaa           string                       `json:"ftaa"`
+	Bfaa           string                       `json:"bfaa"`
+	LastSubmission *Info                        `json:"last_submission"`
+	Submitted      map[string][]SubmittedSource `json:"submitted"`
 	host           string
 	proxy          string
 	path           string
 	client         *http.Client
+	hooks          []string
+	notify         bool
+	apiMutex       sync.Mutex
+	apiLast        time.Time
+	clock          clock
 }
 
 // Instance global client
@@ -38,6 +46,7 @@ func Init(path, host, proxy string) {
 		color.Red(err.Error())
 		color.Green("Create a new session in %v", path)
 	}
+	c.useLangCache()
 	Proxy := http.ProxyFromEnvironment
 	if len(proxy) > 0 {
 		proxyURL, err := url.Parse(proxy)
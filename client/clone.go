@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -32,15 +33,10 @@ func (c *Client) Clone(handle, rootPath string, ac bool) (err error) {
 		}
 	}
 
-	data, err := util.GetJSONBody(c.client, fmt.Sprintf(c.host+"/api/user.status?handle=%v", handle))
-	if err != nil {
+	var submissions []interface{}
+	if err = c.api("user.status", url.Values{"handle": {handle}}, &submissions); err != nil {
 		return
 	}
-
-	if status, ok := data["status"].(string); !ok || status != "OK" {
-		return fmt.Errorf("Cannot get any submission")
-	}
-	submissions := data["result"].([]interface{})
 	total := len(submissions)
 	count := 0
 	color.Cyan("Total submissions: %v", total)
@@ -142,7 +138,7 @@ func (c *Client) Clone(handle, rootPath string, ac bool) (err error) {
 				mu.Unlock()
 				return
 			}
-			ext, ok := LangsExt[lang]
+			ext, ok := LangExt(lang)
 			if !ok {
 				mu.Lock()
 				count++
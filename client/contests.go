@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// Start start time of the contest
+func (c *APIContest) Start() time.Time {
+	return time.Unix(c.StartTimeSeconds, 0)
+}
+
+// Duration duration of the contest
+func (c *APIContest) Duration() time.Duration {
+	return time.Duration(c.DurationSeconds) * time.Second
+}
+
+// URL url of the contest
+func (c *APIContest) URL(host string) string {
+	if c.ID >= 100000 {
+		return fmt.Sprintf("%v/gym/%v", host, c.ID)
+	}
+	return fmt.Sprintf("%v/contest/%v", host, c.ID)
+}
+
+// ContestList contests of codeforces, or of gym
+func (c *Client) ContestList(gym bool) (contests []APIContest, err error) {
+	err = c.api("contest.list", url.Values{"gym": {strconv.FormatBool(gym)}}, &contests)
+	return
+}
+
+// Registrations registration status of upcoming contests by contest id, e.g.
+// "registered" or "open". It's empty if you are not logged in
+func (c *Client) Registrations() (status map[int]string, err error) {
+	body, err := util.GetBody(c.client, c.host+"/contests")
+	if err != nil {
+		return
+	}
+	status = map[int]string{}
+	if _, err = findHandle(body); err != nil {
+		return status, nil
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	doc.Find("tr[data-contestid]").Each(func(_ int, row *goquery.Selection) {
+		id, e := strconv.Atoi(row.AttrOr("data-contestid", ""))
+		if e != nil {
+			return
+		}
+		// The registration cell has either the mark of registered or the
+		// link to register
+		if strings.Contains(strings.ToLower(row.Find(".welldone").Text()), "registration completed") {
+			status[id] = "registered"
+		} else if row.Find(`a[href^="/contestRegistration/"]`).Length() > 0 {
+			status[id] = "open"
+		}
+	})
+	return
+}
+
+// icsEscape escape text of iCalendar
+func icsEscape(text string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(text)
+}
+
+// icsFold fold a content line of iCalendar into lines of 75 octets
+func icsFold(line string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// ContestsICS iCalendar of contests
+func ContestsICS(host string, contests []APIContest) string {
+	const layout = "20060102T150405Z"
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//xalanq//cf-tool//EN\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+	b.WriteString(icsFold("X-WR-CALNAME:Codeforces"))
+	now := time.Now().UTC().Format(layout)
+	domain := host
+	if u, err := url.Parse(host); err == nil {
+		domain = u.Hostname()
+	}
+	for _, contest := range contests {
+		b.WriteString("BEGIN:VEVENT\r\n")
+		b.WriteString(icsFold(fmt.Sprintf("UID:contest-%v@%v", contest.ID, domain)))
+		b.WriteString("DTSTAMP:" + now + "\r\n")
+		b.WriteString("DTSTART:" + contest.Start().UTC().Format(layout) + "\r\n")
+		b.WriteString("DTEND:" + contest.Start().Add(contest.Duration()).UTC().Format(layout) + "\r\n")
+		b.WriteString(icsFold("SUMMARY:" + icsEscape(contest.Name)))
+		b.WriteString(icsFold("URL:" + contest.URL(host)))
+		b.WriteString(icsFold("DESCRIPTION:" + icsEscape(contest.URL(host))))
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
@@ -0,0 +1,314 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// MaxHackTestSize the maximum size of a manual test of a hack. Larger tests
+// should be sent by a generator
+const MaxHackTestSize = 256 * 1024
+
+const (
+	// hackTimeout how long to wait for the verdict of a hack
+	hackTimeout = 10 * time.Minute
+	// maxHackFailures how many failed calls of the api in a row to give up
+	maxHackFailures = 5
+	// hackPollInterval first interval of polling the hacks of the contest,
+	// which is a large list, so it's polled slowly
+	hackPollInterval = 10 * time.Second
+	// maxHackPollInterval polling interval when the verdict does not come
+	maxHackPollInterval = time.Minute
+)
+
+// HackTest the test of a hack. It's either a manual test in Input, or the
+// source of a generator with its arguments
+type HackTest struct {
+	Input         string
+	Generator     string
+	GeneratorLang string
+	GeneratorArgs string
+}
+
+// APIHack hack object of codeforces api
+type APIHack struct {
+	ID                  int64      `json:"id"`
+	CreationTimeSeconds int64      `json:"creationTimeSeconds"`
+	Hacker              APIParty   `json:"hacker"`
+	Defender            APIParty   `json:"defender"`
+	Verdict             string     `json:"verdict"`
+	Problem             APIProblem `json:"problem"`
+}
+
+// hackVerdictText verdicts of hacks as shown on the website
+var hackVerdictText = map[string]string{
+	"HACK_SUCCESSFUL":        "Successful hacking attempt",
+	"HACK_UNSUCCESSFUL":      "Unsuccessful hacking attempt",
+	"INVALID_INPUT":          "Invalid input",
+	"GENERATOR_INCOMPILABLE": "Generator doesn't compile",
+	"GENERATOR_CRASHED":      "Generator crashed",
+	"IGNORED":                "Ignored",
+	"TESTING":                "Testing",
+	"OTHER":                  "Other",
+}
+
+// HackVerdict the text of the verdict of a hack
+func HackVerdict(verdict string) string {
+	if text, ok := hackVerdictText[verdict]; ok {
+		return text
+	}
+	return verdict
+}
+
+// HackRecord a hack made by cf. Info.SubmissionID is the hacked submission
+type HackRecord struct {
+	Info      Info      `json:"info"`
+	HackID    int64     `json:"hack_id,omitempty"`
+	Defender  string    `json:"defender,omitempty"`
+	Problem   string    `json:"problem,omitempty"`
+	Test      string    `json:"test"`
+	Generator string    `json:"generator,omitempty"`
+	Args      string    `json:"args,omitempty"`
+	Verdict   string    `json:"verdict,omitempty"`
+	Submitted time.Time `json:"submitted"`
+}
+
+func (c *Client) hacksPath() string {
+	return filepath.Join(filepath.Dir(c.path), "hacks")
+}
+
+// LoadHacks all hacks made by cf, in order of submitting
+func (c *Client) LoadHacks() (hacks []HackRecord, err error) {
+	data, err := ioutil.ReadFile(c.hacksPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	err = json.Unmarshal(data, &hacks)
+	return
+}
+
+func (c *Client) saveHacks(hacks []HackRecord) (err error) {
+	data, err := json.MarshalIndent(hacks, "", "  ")
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(c.hacksPath()), os.ModePerm)
+	return ioutil.WriteFile(c.hacksPath(), data, 0644)
+}
+
+// ContestHacks all hacks of the contest
+func (c *Client) ContestHacks(info Info) (hacks []APIHack, err error) {
+	if info.ContestID == "" {
+		_, err = info.errorContest()
+		return
+	}
+	err = c.api("contest.hacks", url.Values{"contestId": {info.ContestID}}, &hacks)
+	return
+}
+
+// findSubmissionLang the language in the table of a submission page
+func findSubmissionLang(body []byte) (string, error) {
+	reg := regexp.MustCompile(`<td[^>]*>([^<]*)</td>`)
+	for _, tmp := range reg.FindAllSubmatch(body, -1) {
+		lang := strings.TrimSpace(html.UnescapeString(string(tmp[1])))
+		if _, ok := LangExt(lang); ok {
+			return lang, nil
+		}
+	}
+	return "", errors.New("Cannot find the language of the submission")
+}
+
+// PullSubmission pull the code of any submission which we can see to
+// path with the extension of its language
+func (c *Client) PullSubmission(info Info, path string) (filename string, err error) {
+	URL, err := info.SubmissionURL(c.host)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if message, e := findMessage(body); e == nil {
+		return "", errors.New(message)
+	}
+	lang, err := findSubmissionLang(body)
+	if err != nil {
+		return
+	}
+	ext, _ := LangExt(lang)
+	return c.PullCode(URL, path, "."+ext, false)
+}
+
+// waitHack poll the api until the hack submitted by handle since
+// record.Submitted is judged, the time is out, or Ctrl-C is pressed. The
+// latest state of the hack is written to record
+func (c *Client) waitHack(info Info, handle string, record *HackRecord) (err error) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+	deadline := time.After(hackTimeout)
+	failures := 0
+	interval := hackPollInterval
+	for {
+		select {
+		case <-sig:
+			fmt.Println()
+			return
+		case <-deadline:
+			return
+		case <-time.After(interval):
+		}
+		result, err := c.ContestHacks(info)
+		if err != nil {
+			// The hacks are hidden during some rounds
+			if strings.Contains(strings.ToLower(err.Error()), "not available") {
+				color.Yellow(err.Error())
+				return nil
+			}
+			if failures++; failures >= maxHackFailures {
+				return err
+			}
+			color.Red(err.Error())
+			interval = backoff(interval, hackPollInterval, maxHackPollInterval, false)
+			continue
+		}
+		failures = 0
+		// The latest hack of ours since submitting
+		latest := APIHack{}
+		for _, h := range result {
+			if h.Hacker.Has(handle) && h.ID > latest.ID &&
+				h.CreationTimeSeconds >= record.Submitted.Add(-time.Minute).Unix() {
+				latest = h
+			}
+		}
+		if latest.ID != 0 {
+			record.HackID = latest.ID
+			record.Defender = latest.Defender.Name()
+			record.Problem = latest.Problem.Index
+			record.Verdict = latest.Verdict
+			if latest.Verdict != "" && latest.Verdict != "TESTING" {
+				return nil
+			}
+		}
+		interval = backoff(interval, hackPollInterval, maxHackPollInterval, false)
+	}
+}
+
+// Hack submit a hack of the submission with a manual test or a generator by
+// the web form, then wait for the verdict. The hack is saved to the hack
+// history, as "TESTING" if its verdict is still unknown
+func (c *Client) Hack(info Info, test HackTest) (record HackRecord, err error) {
+	if info.ProblemType != "contest" {
+		return record, fmt.Errorf("Not support hacking in %v", info.ProblemType)
+	}
+	if info.SubmissionID == "" {
+		return record, errors.New(ErrorNeedSubmissionID)
+	}
+	if test.Generator == "" && len(test.Input) > MaxHackTestSize {
+		return record, errors.New("The test is too large for a manual test. Send it by a generator")
+	}
+	color.Cyan("Hack submission %v of contest %v", info.SubmissionID, info.ContestID)
+
+	URL, err := info.ProblemSetURL(c.host)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	handle, err := findHandle(body)
+	if err != nil {
+		return
+	}
+
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+
+	data := url.Values{
+		"csrf_token":   {csrf},
+		"action":       {"challengeFormSubmitted"},
+		"submissionId": {info.SubmissionID},
+		"previousUrl":  {URL},
+	}
+	if test.Generator != "" {
+		data.Set("inputType", "generator")
+		data.Set("generatorSource", test.Generator)
+		data.Set("generatorProgramTypeId", test.GeneratorLang)
+		data.Set("generatorCommandLine", test.GeneratorArgs)
+	} else {
+		data.Set("inputType", "manual")
+		data.Set("testcase", test.Input)
+	}
+	body, err = util.PostBody(c.client, fmt.Sprintf("%v/data/challenge?csrf_token=%v", c.host, csrf), data)
+	if err != nil {
+		return
+	}
+
+	if errMsg, e := findErrorMessage(body); e == nil {
+		return record, errors.New(errMsg)
+	}
+
+	color.Green("Submitted the hack. Waiting for the verdict")
+	record = HackRecord{
+		Info:      info,
+		Test:      test.Input,
+		Generator: test.Generator,
+		Args:      test.GeneratorArgs,
+		Submitted: time.Now(),
+	}
+	record.Info.ProblemID = ""
+	record.Info.RootPath = ""
+	hacks, err := c.LoadHacks()
+	if err != nil {
+		return
+	}
+	hacks = append(hacks, record)
+	index := len(hacks) - 1
+	if err = c.saveHacks(hacks); err != nil {
+		return
+	}
+
+	err = c.waitHack(info, handle, &record)
+	if record.Verdict == "" {
+		record.Verdict = "TESTING"
+	}
+	hacks[index] = record
+	if e := c.saveHacks(hacks); e != nil && err == nil {
+		err = e
+	}
+	if err != nil {
+		return
+	}
+	if record.Verdict == "TESTING" {
+		color.Yellow("Stop waiting. Check the verdict of the hack on the website")
+		return
+	}
+	verdict := HackVerdict(record.Verdict)
+	if record.Verdict == "HACK_SUCCESSFUL" {
+		color.Green("#%v %v: %v", record.HackID, record.Defender, verdict)
+	} else {
+		color.Red("#%v %v: %v", record.HackID, record.Defender, verdict)
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xalanq/cf-tool/util"
+)
+
+// HistoryRecord a submission made by cf
+type HistoryRecord struct {
+	ID           string    `json:"id"`
+	Info         Info      `json:"info"`
+	LangID       string    `json:"lang_id,omitempty"`
+	Hash         string    `json:"hash,omitempty"`
+	Source       string    `json:"source,omitempty"`
+	Submitted    time.Time `json:"submitted"`
+	SubmissionID string    `json:"submission_id,omitempty"`
+	Verdict      string    `json:"verdict,omitempty"`
+	Time         uint64    `json:"time,omitempty"`
+	Memory       uint64    `json:"memory,omitempty"`
+	Judged       time.Time `json:"judged"`
+}
+
+// historyUpdate is appended when a submission is judged
+type historyUpdate struct {
+	ID           string    `json:"id"`
+	SubmissionID string    `json:"submission_id"`
+	Verdict      string    `json:"verdict"`
+	Time         uint64    `json:"time"`
+	Memory       uint64    `json:"memory"`
+	Judged       time.Time `json:"judged"`
+}
+
+func (c *Client) historyPath() string {
+	return filepath.Join(filepath.Dir(c.path), "history")
+}
+
+// appendHistory append one line of json to the history file
+func (c *Client) appendHistory(v interface{}) (err error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(c.historyPath()), os.ModePerm)
+	file, err := os.OpenFile(c.historyPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	return
+}
+
+// LoadHistory all submissions made by cf, in order of submitting
+func (c *Client) LoadHistory() (records []HistoryRecord, err error) {
+	file, err := os.Open(c.historyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer file.Close()
+
+	index := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var id struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &id) != nil {
+			continue
+		}
+		if i, ok := index[id.ID]; ok {
+			json.Unmarshal(scanner.Bytes(), &records[i])
+			continue
+		}
+		var record HistoryRecord
+		if json.Unmarshal(scanner.Bytes(), &record) == nil {
+			index[id.ID] = len(records)
+			records = append(records, record)
+		}
+	}
+	err = scanner.Err()
+	return
+}
+
+// addHistory append a submission right after it is submitted. The
+// duplicate check keeps its own index in the session, see recordSubmitted
+func (c *Client) addHistory(info Info, langID, source string) (id string, err error) {
+	id = util.RandString(12)
+	err = c.appendHistory(HistoryRecord{
+		ID:        id,
+		Info:      info,
+		LangID:    langID,
+		Hash:      hashSource(source),
+		Source:    source,
+		Submitted: time.Now(),
+	})
+	return
+}
+
+// judgeHistory append the final verdict of a submission
+func (c *Client) judgeHistory(id string, submission Submission) error {
+	return c.appendHistory(historyUpdate{
+		ID:           id,
+		SubmissionID: submission.ParseID(),
+		Verdict:      submission.PlainStatus(),
+		Time:         submission.time,
+		Memory:       submission.memory,
+		Judged:       time.Now(),
+	})
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// SubmissionState exported state of a submission
+type SubmissionState struct {
+	ID          uint64 `json:"id"`
+	ProblemType string `json:"problem_type"`
+	ContestID   string `json:"contest_id"`
+	GroupID     string `json:"group_id,omitempty"`
+	Handle      string `json:"handle,omitempty"`
+	Problem     string `json:"problem"`
+	Lang        string `json:"lang"`
+	Verdict     string `json:"verdict"`
+	VerdictType string `json:"verdict_type"`
+	Test        uint64 `json:"test,omitempty"`
+	Passed      uint64 `json:"passed"`
+	Points      uint64 `json:"points,omitempty"`
+	Time        uint64 `json:"time"`
+	Memory      uint64 `json:"memory"`
+	When        string `json:"when"`
+	End         bool   `json:"end"`
+}
+
+// State export the submission of the contest info
+func (s *Submission) State(info Info) SubmissionState {
+	return SubmissionState{
+		ID:          s.id,
+		ProblemType: info.ProblemType,
+		ContestID:   info.ContestID,
+		GroupID:     info.GroupID,
+		Handle:      s.handle,
+		Problem:     s.name,
+		Lang:        s.lang,
+		Verdict:     s.PlainStatus(),
+		VerdictType: s.verdict,
+		Test:        s.test,
+		Passed:      s.passed,
+		Points:      s.points,
+		Time:        s.time,
+		Memory:      s.memory,
+		When:        s.when,
+		End:         s.end,
+	}
+}
+
+// SetVerdictHooks set commands to run and whether to show a desktop
+// notification when a submission is judged
+func (c *Client) SetVerdictHooks(hooks []string, notify bool) {
+	c.hooks = hooks
+	c.notify = notify
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// notifyDesktop show a desktop notification by notify-send (D-Bus) or osascript
+func notifyDesktop(title, text string) error {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.Command("notify-send", "-a", "cf", title, text).Run()
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", text, title)
+		return exec.Command("osascript", "-e", script).Run()
+	}
+	return fmt.Errorf("Desktop notification is not supported in %v", runtime.GOOS)
+}
+
+// judged run hooks and notification for a submission which reaches its final verdict
+func (c *Client) judged(info Info, submission Submission) {
+	if len(c.hooks) == 0 && !c.notify {
+		return
+	}
+	state := submission.State(info)
+	data, _ := json.Marshal(state)
+	env := append(os.Environ(),
+		fmt.Sprintf("CF_SUBMISSION_ID=%v", state.ID),
+		fmt.Sprintf("CF_PROBLEM_TYPE=%v", state.ProblemType),
+		fmt.Sprintf("CF_CONTEST_ID=%v", state.ContestID),
+		fmt.Sprintf("CF_GROUP_ID=%v", state.GroupID),
+		fmt.Sprintf("CF_HANDLE=%v", state.Handle),
+		fmt.Sprintf("CF_PROBLEM=%v", state.Problem),
+		fmt.Sprintf("CF_LANG=%v", state.Lang),
+		fmt.Sprintf("CF_VERDICT=%v", state.Verdict),
+		fmt.Sprintf("CF_VERDICT_TYPE=%v", state.VerdictType),
+		fmt.Sprintf("CF_TEST=%v", state.Test),
+		fmt.Sprintf("CF_PASSED=%v", state.Passed),
+		fmt.Sprintf("CF_POINTS=%v", state.Points),
+		fmt.Sprintf("CF_TIME=%v", state.Time),
+		fmt.Sprintf("CF_MEMORY=%v", state.Memory),
+	)
+	for _, hook := range c.hooks {
+		cmd := shellCommand(hook)
+		cmd.Env = env
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			color.Red("Hook %v: %v", hook, err.Error())
+		}
+	}
+	if c.notify {
+		title := fmt.Sprintf("%v #%v", strings.TrimSpace(state.Problem), state.ID)
+		if err := notifyDesktop(title, state.Verdict); err != nil {
+			color.Red("Notification: %v", err.Error())
+		}
+	}
+}
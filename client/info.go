@@ -13,6 +13,7 @@ var ProblemTypes = [...]string{
 	"gym",
 	"group",
 	"acmsguru",
+	"problemset",
 }
 
 // Info information
@@ -107,6 +108,8 @@ func (info *Info) ProblemSetURL(host string) (string, error) {
 		return fmt.Sprintf(host+"/group/%v/contest/%v", info.GroupID, info.ContestID), nil
 	case "acmsguru":
 		return host + "/problemsets/acmsguru", nil
+	case "problemset":
+		return fmt.Sprintf(host+"/contest/%v", info.ContestID), nil
 	}
 	return "", errors.New(ErrorUnknownType)
 }
@@ -131,6 +134,8 @@ func (info *Info) ProblemURL(host string) (string, error) {
 		return fmt.Sprintf(host+"/group/%v/contest/%v/problem/%v", info.GroupID, info.ContestID, info.ProblemID), nil
 	case "acmsguru":
 		return fmt.Sprintf(host+"/problemsets/acmsguru/problem/%v/%v", info.ContestID, info.ProblemID), nil
+	case "problemset":
+		return fmt.Sprintf(host+"/problemset/problem/%v/%v", info.ContestID, info.ProblemID), nil
 	}
 	return "", errors.New(ErrorUnknownType)
 }
@@ -141,7 +146,7 @@ func (info *Info) MySubmissionURL(host string) (string, error) {
 		return info.errorContest()
 	}
 	switch info.ProblemType {
-	case "contest":
+	case "contest", "problemset":
 		return fmt.Sprintf(host+"/contest/%v/my", info.ContestID), nil
 	case "gym":
 		return fmt.Sprintf(host+"/gym/%v/my", info.ContestID), nil
@@ -165,7 +170,7 @@ func (info *Info) SubmissionURL(host string) (string, error) {
 		return info.errorContest()
 	}
 	switch info.ProblemType {
-	case "contest":
+	case "contest", "problemset":
 		return fmt.Sprintf(host+"/contest/%v/submission/%v", info.ContestID, info.SubmissionID), nil
 	case "gym":
 		return fmt.Sprintf(host+"/gym/%v/submission/%v", info.ContestID, info.SubmissionID), nil
@@ -186,7 +191,7 @@ func (info *Info) StandingsURL(host string) (string, error) {
 		return info.errorContest()
 	}
 	switch info.ProblemType {
-	case "contest":
+	case "contest", "problemset":
 		return fmt.Sprintf(host+"/contest/%v/standings", info.ContestID), nil
 	case "gym":
 		return fmt.Sprintf(host+"/gym/%v/standings", info.ContestID), nil
@@ -203,6 +208,12 @@ func (info *Info) StandingsURL(host string) (string, error) {
 
 // SubmitURL submit url
 func (info *Info) SubmitURL(host string) (string, error) {
+	if info.ProblemType == "problemset" {
+		if info.ContestID == "" {
+			return info.errorContest()
+		}
+		return host + "/problemset/submit", nil
+	}
 	URL, err := info.ProblemSetURL(host)
 	if err != nil {
 		return "", err
@@ -241,6 +252,11 @@ func (info *Info) OpenURL(host string) (string, error) {
 			return host + "/problemsets/acmsguru/", nil
 		}
 		return fmt.Sprintf(host+"/problemsets/acmsguru/problem/%v/%v", info.ContestID, info.ProblemID), nil
+	case "problemset":
+		if info.ContestID == "" || info.ProblemID == "" {
+			return host + "/problemset", nil
+		}
+		return fmt.Sprintf(host+"/problemset/problem/%v/%v", info.ContestID, info.ProblemID), nil
 	}
 	return "", errors.New("Hmmm I don't know what you want to do~")
 }
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// langCache languages fetched from the submit page. ExtOverrides is kept
+// across updates so that users can fix the extension of any language
+type langCache struct {
+	Langs        map[string]string `json:"langs"`
+	ExtOverrides map[string]string `json:"ext_overrides"`
+	Updated      time.Time         `json:"updated"`
+}
+
+// extOverrides extensions set by user, by language name
+var extOverrides = map[string]string{}
+
+// extRules heuristic rules to guess the extension by language name. The
+// first matched rule wins
+var extRules = []struct {
+	reg *regexp.Regexp
+	ext string
+}{
+	{regexp.MustCompile(`(?i)pascal|delphi|\bfpc\b`), "pas"},
+	{regexp.MustCompile(`(?i)c\+\+|g\+\+|\bmsvc\b`), "cpp"},
+	{regexp.MustCompile(`(?i)c#|mono|\.net`), "cs"},
+	{regexp.MustCompile(`(?i)\bgcc\b|\bc11\b|\bc\b`), "c"},
+	{regexp.MustCompile(`(?i)kotlin`), "kt"},
+	{regexp.MustCompile(`(?i)javascript|node\.js|\bv8\b`), "js"},
+	{regexp.MustCompile(`(?i)java`), "java"},
+	{regexp.MustCompile(`(?i)python|pypy`), "py"},
+	{regexp.MustCompile(`(?i)rust`), "rs"},
+	{regexp.MustCompile(`(?i)\bgo\b`), "go"},
+	{regexp.MustCompile(`(?i)haskell`), "hs"},
+	{regexp.MustCompile(`(?i)scala`), "scala"},
+	{regexp.MustCompile(`(?i)ocaml`), "ml"},
+	{regexp.MustCompile(`(?i)\bd\b|\bdmd\b`), "d"},
+	{regexp.MustCompile(`(?i)perl`), "pl"},
+	{regexp.MustCompile(`(?i)php`), "php"},
+	{regexp.MustCompile(`(?i)ruby`), "rb"},
+	{regexp.MustCompile(`(?i)q#`), "qs"},
+	{regexp.MustCompile(`(?i)f#`), "fs"},
+}
+
+// LangExt the extension of a language name, e.g. "GNU C++17" or
+// "GNU G++17 7.3.0"
+func LangExt(name string) (string, bool) {
+	if ext, ok := extOverrides[name]; ok {
+		return ext, true
+	}
+	if ext, ok := LangsExt[name]; ok {
+		return ext, true
+	}
+	for _, rule := range extRules {
+		if rule.reg.MatchString(name) {
+			return rule.ext, true
+		}
+	}
+	return "", false
+}
+
+func (c *Client) langCachePath() string {
+	return filepath.Join(filepath.Dir(c.path), "langs")
+}
+
+func (c *Client) loadLangCache() (cache langCache, err error) {
+	data, err := ioutil.ReadFile(c.langCachePath())
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &cache)
+	return
+}
+
+func (c *Client) saveLangCache(cache langCache) (err error) {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err == nil {
+		os.MkdirAll(filepath.Dir(c.langCachePath()), os.ModePerm)
+		err = ioutil.WriteFile(c.langCachePath(), data, 0644)
+	}
+	return
+}
+
+// FetchedLangs the languages fetched by the last update, nil if they have
+// never been fetched
+func (c *Client) FetchedLangs() map[string]string {
+	cache, err := c.loadLangCache()
+	if err != nil || len(cache.Langs) == 0 {
+		return nil
+	}
+	return cache.Langs
+}
+
+// useLangCache replace the built-in languages by the cached ones
+func (c *Client) useLangCache() {
+	cache, err := c.loadLangCache()
+	if err != nil {
+		return
+	}
+	if len(cache.Langs) > 0 {
+		Langs = cache.Langs
+	}
+	if cache.ExtOverrides != nil {
+		extOverrides = cache.ExtOverrides
+	}
+}
+
+func findLangs(body []byte) (map[string]string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	langs := map[string]string{}
+	doc.Find(`select[name="programTypeId"] option`).Each(func(_ int, s *goquery.Selection) {
+		if id, ok := s.Attr("value"); ok && id != "" {
+			langs[id] = strings.TrimSpace(s.Text())
+		}
+	})
+	if len(langs) == 0 {
+		return nil, errors.New("Cannot find any language")
+	}
+	return langs, nil
+}
+
+// UpdateLangs fetch the languages from the submit page and cache them.
+// Return the languages which are added and removed
+func (c *Client) UpdateLangs() (added, removed map[string]string, err error) {
+	color.Cyan("Fetch languages from the submit page")
+
+	body, err := util.GetBody(c.client, c.host+"/problemset/submit")
+	if err != nil {
+		return
+	}
+
+	if _, err = findHandle(body); err != nil {
+		return
+	}
+
+	langs, err := findLangs(body)
+	if err != nil {
+		return
+	}
+
+	cache, _ := c.loadLangCache()
+	if cache.ExtOverrides == nil {
+		cache.ExtOverrides = map[string]string{}
+	}
+	added, removed = map[string]string{}, map[string]string{}
+	for id, name := range langs {
+		if _, ok := Langs[id]; !ok {
+			added[id] = name
+		}
+	}
+	for id, name := range Langs {
+		if _, ok := langs[id]; !ok {
+			removed[id] = name
+		}
+	}
+	cache.Langs = langs
+	cache.Updated = time.Now()
+	if err = c.saveLangCache(cache); err != nil {
+		return
+	}
+	Langs = langs
+	extOverrides = cache.ExtOverrides
+	return
+}
@@ -3,6 +3,8 @@ package client
 // Langs generated by
 // ^[\s\S]*?value="(.+?)"[\s\S]*?>([\s\S]+?)<[\s\S]*?$
 //     "\1": "\2",
+// These are the defaults. "cf langs update" replaces them by the languages of
+// the submit page
 var Langs = map[string]string{
 	"43": "GNU GCC C11 5.1.0",
 	"52": "Clang++17 Diagnostics",
@@ -51,7 +53,7 @@ var Langs = map[string]string{
 	"56": "Microsoft Q#",
 }
 
-// LangsExt language's ext
+// LangsExt language's ext. Use LangExt for names which are not listed
 var LangsExt = map[string]string{
 	"GNU C11":               "c",
 	"Clang++17 Diagnostics": "cpp",
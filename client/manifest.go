@@ -0,0 +1,100 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestName the file which records the samples fetched from the site
+const ManifestName = ".samples.json"
+
+// sampleManifest maps a sample file name to the hash of the content we wrote
+type sampleManifest struct {
+	Files map[string]string `json:"files"`
+	path  string
+}
+
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func loadManifest(dir string) *sampleManifest {
+	m := &sampleManifest{Files: map[string]string{}, path: filepath.Join(dir, ManifestName)}
+	if data, err := ioutil.ReadFile(m.path); err == nil {
+		json.Unmarshal(data, m)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return m
+}
+
+func (m *sampleManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(m.path, data, 0644)
+}
+
+// Sample file states
+const (
+	sampleNew = iota
+	sampleSame
+	sampleUntouched
+	sampleEdited
+)
+
+// state of name compared with the manifest and the fetched data
+func (m *sampleManifest) state(name string, data []byte) int {
+	old, err := ioutil.ReadFile(filepath.Join(filepath.Dir(m.path), name))
+	if err != nil {
+		return sampleNew
+	}
+	hash := hashData(old)
+	if hash == hashData(data) {
+		return sampleSame
+	}
+	if m.Files[name] == hash {
+		return sampleUntouched
+	}
+	return sampleEdited
+}
+
+// write data to name and record its hash
+func (m *sampleManifest) write(name string, data []byte) error {
+	if err := ioutil.WriteFile(filepath.Join(filepath.Dir(m.path), name), data, 0644); err != nil {
+		return err
+	}
+	m.Files[name] = hashData(data)
+	return nil
+}
+
+// prune remove files which are no longer fetched. Edited files are handed
+// over to the user. Return the names which are kept
+func (m *sampleManifest) prune(fetched map[string]bool) (kept []string) {
+	for name, hash := range m.Files {
+		if fetched[name] {
+			continue
+		}
+		delete(m.Files, name)
+		path := filepath.Join(filepath.Dir(m.path), name)
+		old, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if hashData(old) == hash {
+			os.Remove(path)
+		} else {
+			kept = append(kept, name)
+		}
+	}
+	sort.Strings(kept)
+	return
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"fmt"
 	"html"
-	"io/ioutil"
+	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/xalanq/cf-tool/util"
 
@@ -40,6 +42,7 @@ func findSample(body []byte) (input [][]byte, output [][]byte, err error) {
 }
 
 // ParseProblem parse problem to path. mu can be nil
+// Samples which were edited locally or added by the user are never overwritten
 func (c *Client) ParseProblem(URL, path string, mu *sync.Mutex) (samples int, standardIO bool, err error) {
 	body, err := util.GetBody(c.client, URL)
 	if err != nil {
@@ -61,34 +64,77 @@ func (c *Client) ParseProblem(URL, path string, mu *sync.Mutex) (samples int, st
 		standardIO = false
 	}
 
+	warn := func(format string, a ...interface{}) {
+		if mu != nil {
+			mu.Lock()
+		}
+		color.Yellow(format, a...)
+		if mu != nil {
+			mu.Unlock()
+		}
+	}
+
+	manifest := loadManifest(path)
+	fetched := map[string]bool{}
 	for i := 0; i < len(input); i++ {
-		fileIn := filepath.Join(path, fmt.Sprintf("in%v.txt", i+1))
-		fileOut := filepath.Join(path, fmt.Sprintf("ans%v.txt", i+1))
-		e := ioutil.WriteFile(fileIn, input[i], 0644)
-		if e != nil {
-			if mu != nil {
-				mu.Lock()
-			}
-			color.Red(e.Error())
-			if mu != nil {
-				mu.Unlock()
-			}
+		fileIn := fmt.Sprintf("in%v.txt", i+1)
+		fileOut := fmt.Sprintf("ans%v.txt", i+1)
+		fetched[fileIn] = true
+		fetched[fileOut] = true
+		stateIn := manifest.state(fileIn, input[i])
+		stateOut := manifest.state(fileOut, output[i])
+		if stateIn == sampleEdited || stateOut == sampleEdited {
+			warn("Conflict %v: sample %v was changed locally, keep yours", filepath.Base(path), i+1)
+			continue
 		}
-		e = ioutil.WriteFile(fileOut, output[i], 0644)
-		if e != nil {
-			if mu != nil {
-				mu.Lock()
-			}
-			color.Red(e.Error())
-			if mu != nil {
-				mu.Unlock()
-			}
+		if stateIn == sampleUntouched || stateOut == sampleUntouched {
+			warn("Update %v: sample %v was changed on the site", filepath.Base(path), i+1)
+		}
+		if err = manifest.write(fileIn, input[i]); err != nil {
+			return
+		}
+		if err = manifest.write(fileOut, output[i]); err != nil {
+			return
 		}
 	}
+	for _, name := range manifest.prune(fetched) {
+		warn("Conflict %v: %v was removed on the site, keep yours", filepath.Base(path), name)
+	}
+	if err = manifest.save(); err != nil {
+		return
+	}
 	return len(input), standardIO, nil
 }
 
-// Parse parse
+// parseRetry is the number of attempts for a problem before giving up
+const parseRetry = 3
+
+// isNetworkError reports whether err came from the connection rather than the page
+func isNetworkError(err error) bool {
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// parseProblemRetry call ParseProblem and retry with backoff on network errors
+func (c *Client) parseProblemRetry(URL, path string, mu *sync.Mutex) (samples int, standardIO bool, err error) {
+	wait := time.Second
+	for i := 1; ; i++ {
+		samples, standardIO, err = c.ParseProblem(URL, path, mu)
+		if err == nil || !isNetworkError(err) || i >= parseRetry {
+			return
+		}
+		mu.Lock()
+		color.Yellow("Retry %v in %v. Error: %v", URL, wait, err.Error())
+		mu.Unlock()
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// Parse parse. paths only contains the problems which were parsed successfully
 func (c *Client) Parse(info Info) (problems []string, paths []string, err error) {
 	color.Cyan("Parse " + info.Hint())
 
@@ -117,24 +163,19 @@ func (c *Client) Parse(info Info) (problems []string, paths []string, err error)
 	wg := sync.WaitGroup{}
 	wg.Add(len(problems))
 	mu := sync.Mutex{}
-	paths = make([]string, len(problems))
+	errs := make([]error, len(problems))
 	for i, problemID := range problems {
-		paths[i] = filepath.Join(contestPath, strings.ToLower(problemID))
-		go func(problemID, path string) {
+		go func(i int, problemID, path string) {
 			defer wg.Done()
 			mu.Lock()
 			fmt.Printf("Parsing %v\n", problemID)
 			mu.Unlock()
 
-			err = os.MkdirAll(path, os.ModePerm)
-			if err != nil {
-				return
-			}
-			URL := fmt.Sprintf(urlFormatter, problemID)
-
-			samples, standardIO, err := c.ParseProblem(URL, path, &mu)
-			if err != nil {
-				return
+			samples, standardIO := 0, true
+			err := os.MkdirAll(path, os.ModePerm)
+			if err == nil {
+				URL := fmt.Sprintf(urlFormatter, problemID)
+				samples, standardIO, err = c.parseProblemRetry(URL, path, &mu)
 			}
 
 			warns := ""
@@ -148,8 +189,24 @@ func (c *Client) Parse(info Info) (problems []string, paths []string, err error)
 				ansi.Printf("%v %v\n", color.GreenString("Parsed %v with %v samples.", problemID, samples), warns)
 			}
 			mu.Unlock()
-		}(problemID, paths[i])
+			errs[i] = err
+		}(i, problemID, filepath.Join(contestPath, strings.ToLower(problemID)))
 	}
 	wg.Wait()
+
+	failed := []string{}
+	for i, e := range errs {
+		if e == nil {
+			paths = append(paths, filepath.Join(contestPath, strings.ToLower(problems[i])))
+			continue
+		}
+		if e.Error() == ErrorNotLogged {
+			return problems, paths, e
+		}
+		failed = append(failed, problems[i])
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("Failed to parse %v of %v problem(s): %v", len(failed), len(problems), strings.Join(failed, ", "))
+	}
 	return
 }
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// ProblemsetInfo problem of the problemset with its statistics
+type ProblemsetInfo struct {
+	APIProblem
+	SolvedCount int
+}
+
+// Problemset get all problems which have all the tags
+func (c *Client) Problemset(tags []string) (problems []ProblemsetInfo, err error) {
+	color.Cyan("Fetch problemset")
+
+	query := url.Values{}
+	if len(tags) > 0 {
+		query.Set("tags", strings.Join(tags, ";"))
+	}
+	var result struct {
+		Problems   []APIProblem `json:"problems"`
+		Statistics []struct {
+			ContestID   int    `json:"contestId"`
+			Index       string `json:"index"`
+			SolvedCount int    `json:"solvedCount"`
+		} `json:"problemStatistics"`
+	}
+	if err = c.api("problemset.problems", query, &result); err != nil {
+		return
+	}
+
+	solved := map[string]int{}
+	for _, s := range result.Statistics {
+		p := APIProblem{ContestID: s.ContestID, Index: s.Index}
+		solved[p.ID()] = s.SolvedCount
+	}
+	for _, p := range result.Problems {
+		problems = append(problems, ProblemsetInfo{p, solved[p.ID()]})
+	}
+	return
+}
+
+// SolvedProblems ids of all problems which handle has accepted
+func (c *Client) SolvedProblems(handle string) (solved map[string]bool, err error) {
+	submissions, err := c.UserStatus(handle)
+	if err != nil {
+		return
+	}
+	solved = map[string]bool{}
+	for _, s := range submissions {
+		if s.Verdict == "OK" {
+			solved[s.Problem.ID()] = true
+		}
+	}
+	return
+}
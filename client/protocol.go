@@ -0,0 +1,156 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// ErrorTestsNotPublic error
+const ErrorTestsNotPublic = "The tests are not public yet"
+
+// FailedTest data of the test on which a submission failed
+type FailedTest struct {
+	Test      uint64
+	Input     string
+	Output    string
+	Answer    string
+	Checker   string
+	Truncated bool
+}
+
+// submissionCsrf csrf token of the submission page
+func (c *Client) submissionCsrf(info Info) (string, error) {
+	URL, err := info.SubmissionURL(c.host)
+	if err != nil {
+		return "", err
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return "", err
+	}
+	return findCsrf(body)
+}
+
+// JudgeProtocol the compiler message of a submission with compilation error
+func (c *Client) JudgeProtocol(info Info) (msg string, err error) {
+	csrf, err := c.submissionCsrf(info)
+	if err != nil {
+		return
+	}
+	body, err := util.PostBody(c.client, c.host+"/data/judgeProtocol", url.Values{
+		"submissionId": {info.SubmissionID},
+		"csrf_token":   {csrf},
+	})
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(body, &msg); err != nil {
+		return "", errors.New("Cannot find the judge protocol")
+	}
+	return
+}
+
+// FailedTest the data of the failed test, which is available once the tests
+// are public (e.g. after the contest)
+func (c *Client) FailedTest(info Info, test uint64) (ret *FailedTest, err error) {
+	csrf, err := c.submissionCsrf(info)
+	if err != nil {
+		return
+	}
+	body, err := util.PostBody(c.client, c.host+"/data/submitSource", url.Values{
+		"submissionId": {info.SubmissionID},
+		"csrf_token":   {csrf},
+	})
+	if err != nil {
+		return
+	}
+	var data map[string]interface{}
+	if err = json.Unmarshal(body, &data); err != nil {
+		return
+	}
+	get := func(key string) string {
+		if v, ok := data[fmt.Sprintf("%v#%v", key, test)].(string); ok {
+			return v
+		}
+		return ""
+	}
+	if _, ok := data[fmt.Sprintf("input#%v", test)]; !ok {
+		return nil, errors.New(ErrorTestsNotPublic)
+	}
+	ret = &FailedTest{
+		Test:    test,
+		Input:   get("input"),
+		Output:  get("output"),
+		Answer:  get("answer"),
+		Checker: get("checkerStdoutAndStderr"),
+	}
+	// Large tests are cut with "..." on the page
+	for _, s := range []string{ret.Input, ret.Answer} {
+		if strings.HasSuffix(strings.TrimSpace(s), "...") {
+			ret.Truncated = true
+		}
+	}
+	return
+}
+
+// Save the test as a new sample pair in the folder, return the index of it
+func (t *FailedTest) Save(path string) (index int, err error) {
+	if err = os.MkdirAll(path, os.ModePerm); err != nil {
+		return
+	}
+	exist := func(name string) bool {
+		_, err := os.Stat(filepath.Join(path, name))
+		return err == nil
+	}
+	index = 1
+	for exist(fmt.Sprintf("in%v.txt", index)) || exist(fmt.Sprintf("ans%v.txt", index)) {
+		index++
+	}
+	if err = ioutil.WriteFile(filepath.Join(path, fmt.Sprintf("in%v.txt", index)), []byte(t.Input), 0644); err != nil {
+		return
+	}
+	err = ioutil.WriteFile(filepath.Join(path, fmt.Sprintf("ans%v.txt", index)), []byte(t.Answer), 0644)
+	return
+}
+
+// ShowFailure print the compiler message or the failed test of a judged
+// submission. Return the failed test if its data is available
+func (c *Client) ShowFailure(info Info, submission Submission) (test *FailedTest, err error) {
+	info.SubmissionID = submission.ParseID()
+	if submission.verdict == "COMPILATION_ERROR" {
+		msg, err := c.JudgeProtocol(info)
+		if err != nil {
+			return nil, err
+		}
+		color.Red("Compilation error of #%v:", info.SubmissionID)
+		fmt.Println(strings.TrimRight(msg, "\r\n"))
+		return nil, nil
+	}
+	if _, ok := verdictAbbr[submission.verdict]; !ok || submission.test == 0 {
+		return nil, nil
+	}
+	if test, err = c.FailedTest(info, submission.test); err != nil {
+		return
+	}
+	color.Red("Test %v of #%v:", test.Test, info.SubmissionID)
+	show := func(title, text string) {
+		if text = strings.TrimRight(text, "\r\n"); text != "" {
+			color.Cyan(title)
+			fmt.Println(text)
+		}
+	}
+	show("Input", test.Input)
+	show("Output", test.Output)
+	show("Answer", test.Answer)
+	show("Checker log", test.Checker)
+	return
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// minPollInterval polling interval right after a change
+	minPollInterval = time.Second
+	// maxPollInterval polling interval when nothing changes for a while
+	maxPollInterval = 10 * time.Second
+	// pushPollInterval polling interval while the push channel is alive
+	pushPollInterval = 30 * time.Second
+)
+
+// clock the time of watching submissions, which tests replace by a fake one
+type clock interface {
+	Now() time.Time
+	// NewTimer send the time to the channel after d. Call stop to release it
+	NewTimer(d time.Duration) (c <-chan time.Time, stop func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	timer := time.NewTimer(d)
+	return timer.C, func() { timer.Stop() }
+}
+
+func (c *Client) getClock() clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+	return c.clock
+}
+
+// findPushChannel the personal pushstream channel of the page, on which
+// codeforces publishes updates of your submissions
+func findPushChannel(body []byte) string {
+	reg := regexp.MustCompile(`name="pc" content="([^"]+?)"`)
+	tmp := reg.FindSubmatch(body)
+	if tmp == nil {
+		return ""
+	}
+	return string(tmp[1])
+}
+
+// pushHost host of the pushstream server, e.g. "https://pubsub.codeforces.com".
+// A local host (e.g. a mirror for testing) serves the channel by itself
+func pushHost(host string) string {
+	u, err := url.Parse(host)
+	if err != nil {
+		return host
+	}
+	hostname := u.Hostname()
+	if hostname == "localhost" || net.ParseIP(hostname) != nil {
+		return host
+	}
+	u.Host = "pubsub." + u.Host
+	return u.String()
+}
+
+// subscribe listen to the pushstream channel in the EventSource mode, which
+// is served by the same server as the websocket mode of the web page. A
+// signal is sent to the returned channel whenever a message arrives, and the
+// channel is closed once the connection is lost or ctx is done
+func (c *Client) subscribe(ctx context.Context, channel string) <-chan struct{} {
+	notify := make(chan struct{}, 1)
+	go func() {
+		defer close(notify)
+		URL := pushHost(c.host) + "/ev/s_" + channel
+		req, err := http.NewRequest("GET", URL, nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return
+		}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if !strings.HasPrefix(scanner.Text(), "data:") {
+				continue
+			}
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return notify
+}
+
+// backoff the next polling interval. It's reset to min once something
+// changes, otherwise it grows by half until max
+func backoff(interval, min, max time.Duration, changed bool) time.Duration {
+	if changed {
+		return min
+	}
+	interval += interval / 2
+	if interval < min {
+		return min
+	}
+	if interval > max {
+		return max
+	}
+	return interval
+}
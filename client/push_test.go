@@ -0,0 +1,295 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xalanq/cf-tool/cookiejar"
+)
+
+const fakeRow = `<tr data-submission-id="12"><td class="id-cell">12</td><td class="status-small"><span class="format-time">Oct/19/2026 12:00</span></td><td data-problemId="1">A - Foo</td><td>GNU G++17 7.3.0</td><td class="status-cell">%v</td><td class="time-consumed-cell">15 ms</td><td class="memory-consumed-cell">100 KB</td></tr>`
+
+const fakeTesting = `<span class='submissionVerdictWrapper' submissionVerdict="TESTING"><span class='verdict-waiting'>Running on test <span class='verdict-format-judged'>3</span></span></span>`
+
+const fakeAccepted = `<span class='submissionVerdictWrapper' submissionVerdict="OK"><span class='verdict-accepted'>Accepted</span></span>`
+
+// failAfter how long to wait for the watcher before the test fails. Time of
+// the watcher itself is faked, so this only guards against hanging
+const failAfter = 10 * time.Second
+
+// fakeTimer a timer of fakeClock
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+// fakeClock a clock which only moves when the test advances it
+type fakeClock struct {
+	sync.Mutex
+	now     time.Time
+	timers  map[*fakeTimer]bool
+	changed chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		timers:  map[*fakeTimer]bool{},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.Lock()
+	defer f.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	f.Lock()
+	defer f.Unlock()
+	t := &fakeTimer{at: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- f.now
+		return t.c, func() {}
+	}
+	f.timers[t] = true
+	f.signal()
+	return t.c, func() {
+		f.Lock()
+		defer f.Unlock()
+		delete(f.timers, t)
+		f.signal()
+	}
+}
+
+func (f *fakeClock) signal() {
+	select {
+	case f.changed <- struct{}{}:
+	default:
+	}
+}
+
+// wait until the watcher is blocked on n timers
+func (f *fakeClock) wait(t *testing.T, n int) {
+	deadline := time.After(failAfter)
+	for {
+		f.Lock()
+		blocked := len(f.timers) == n
+		f.Unlock()
+		if blocked {
+			return
+		}
+		select {
+		case <-f.changed:
+		case <-deadline:
+			t.Fatalf("the watcher does not wait on %v timer(s)", n)
+		}
+	}
+}
+
+// step wait until the watcher is blocked on n timers, then move to the
+// earliest one and fire all timers due by then
+func (f *fakeClock) step(t *testing.T, n int) {
+	f.wait(t, n)
+	f.Lock()
+	defer f.Unlock()
+	earliest := time.Time{}
+	for timer := range f.timers {
+		if earliest.IsZero() || timer.at.Before(earliest) {
+			earliest = timer.at
+		}
+	}
+	if earliest.After(f.now) {
+		f.now = earliest
+	}
+	for timer := range f.timers {
+		if !timer.at.After(f.now) {
+			timer.c <- f.now
+			delete(f.timers, timer)
+		}
+	}
+}
+
+// fakePush a fake codeforces serving the page of your submissions with the
+// meta "pc" channel, and the pushstream of the channel at /ev/s_<channel>.
+// Times are read from the fake clock
+type fakePush struct {
+	sync.Mutex
+	clock      *fakeClock
+	judged     bool
+	fetches    []time.Time
+	subscribes []time.Time
+	subscribed chan int
+	// stream serves the i-th subscription, which is dropped on return
+	stream func(i int, w http.ResponseWriter, flush func())
+}
+
+func newFakePush(stream func(i int, w http.ResponseWriter, flush func())) *fakePush {
+	return &fakePush{clock: newFakeClock(), subscribed: make(chan int, 16), stream: stream}
+}
+
+func (f *fakePush) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/contest/1/my":
+		f.Lock()
+		f.fetches = append(f.fetches, f.clock.Now())
+		status := fakeTesting
+		if f.judged {
+			status = fakeAccepted
+		}
+		f.Unlock()
+		fmt.Fprintf(w, `<script>var handle = "me";</script><meta name="utc_offset" content="+03:00"/><meta name="pc" content="abc"/><table>`+fakeRow+`</table>`, status)
+	case "/ev/s_abc":
+		f.Lock()
+		f.subscribes = append(f.subscribes, f.clock.Now())
+		i := len(f.subscribes) - 1
+		f.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		flusher.Flush()
+		f.subscribed <- i
+		f.stream(i, w, flusher.Flush)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// push judge the submission and tell it by the stream
+func (f *fakePush) push(w http.ResponseWriter, flush func()) {
+	f.Lock()
+	f.judged = true
+	f.Unlock()
+	fmt.Fprint(w, "data: {\"text\":\"s\"}\n\n")
+	flush()
+}
+
+// watch start watching the fake codeforces. Call the returned function to
+// wait for the end
+func (f *fakePush) watch(t *testing.T) (wait func()) {
+	server := httptest.NewServer(f)
+	jar, _ := cookiejar.New(nil)
+	c := &Client{Jar: jar, host: server.URL, client: &http.Client{Jar: jar}, clock: f.clock}
+	done := make(chan error, 1)
+	go func() {
+		info := Info{ProblemType: "contest", ContestID: "1"}
+		_, err := c.watchSubmission(info, nil, 1, false, func([]Submission, bool) {})
+		done <- err
+	}()
+	return func() {
+		defer server.Close()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(failAfter):
+			t.Fatal("watch does not end")
+		}
+	}
+}
+
+// waitSubscribe wait for the i-th subscription
+func (f *fakePush) waitSubscribe(t *testing.T, i int) {
+	select {
+	case j := <-f.subscribed:
+		if j != i {
+			t.Fatalf("subscription #%v, want #%v", j, i)
+		}
+	case <-time.After(failAfter):
+		t.Fatalf("no subscription #%v", i)
+	}
+}
+
+func TestWatchRefetchOnPush(t *testing.T) {
+	pushed := make(chan bool)
+	f := newFakePush(nil)
+	f.stream = func(i int, w http.ResponseWriter, flush func()) {
+		<-pushed
+		f.push(w, flush)
+	}
+	wait := f.watch(t)
+	f.waitSubscribe(t, 0)
+	// Poll a few times, so the polling interval grows beyond the minimum
+	for i := 0; i < 4; i++ {
+		f.clock.step(t, 1)
+	}
+	// Push only after the last poll
+	f.clock.wait(t, 1)
+	pushed <- true
+	// The poll timer and the wait for the minimum interval
+	f.clock.step(t, 2)
+	wait()
+
+	f.Lock()
+	defer f.Unlock()
+	if len(f.fetches) != 6 {
+		t.Fatalf("fetch %v times, want 6", len(f.fetches))
+	}
+	if gap := f.fetches[5].Sub(f.fetches[4]); gap != minPollInterval {
+		t.Fatalf("refetch %v after the last poll, want %v", gap, minPollInterval)
+	}
+}
+
+func TestWatchPollAndReconnectWithBackoff(t *testing.T) {
+	drop := make(chan bool)
+	f := newFakePush(nil)
+	f.stream = func(i int, w http.ResponseWriter, flush func()) {
+		if i == 3 {
+			f.push(w, flush)
+			return
+		}
+		<-drop
+	}
+	wait := f.watch(t)
+
+	f.waitSubscribe(t, 0)
+	drop <- true
+	// Wait for the minimum interval, then poll and subscribe at once
+	f.clock.step(t, 2)
+
+	f.waitSubscribe(t, 1)
+	drop <- true
+	// Wait for the minimum interval and poll, then subscribe
+	f.clock.step(t, 2)
+	f.clock.step(t, 2)
+
+	f.waitSubscribe(t, 2)
+	drop <- true
+	// Poll twice while waiting for subscribing again
+	f.clock.step(t, 2)
+	f.clock.step(t, 2)
+	f.clock.step(t, 2)
+
+	f.waitSubscribe(t, 3)
+	f.clock.step(t, 2)
+	wait()
+
+	f.Lock()
+	defer f.Unlock()
+	if len(f.subscribes) != 4 {
+		t.Fatalf("subscribe %v times, want 4", len(f.subscribes))
+	}
+	for i := 2; i < len(f.subscribes); i++ {
+		prev := f.subscribes[i-1].Sub(f.subscribes[i-2])
+		gap := f.subscribes[i].Sub(f.subscribes[i-1])
+		if gap <= prev {
+			t.Fatalf("reconnect after %v, not longer than %v before", gap, prev)
+		}
+	}
+	// Polling goes on while the push channel is lost
+	polls := 0
+	for _, at := range f.fetches {
+		if at.After(f.subscribes[2]) && at.Before(f.subscribes[3]) {
+			polls++
+		}
+	}
+	if polls < 2 {
+		t.Fatalf("poll %v times while the push channel is lost, want at least 2", polls)
+	}
+}
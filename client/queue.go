@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// QueueJob a submission which waits for its time
+type QueueJob struct {
+	ID       int       `json:"id"`
+	Info     Info      `json:"info"`
+	LangID   string    `json:"lang_id"`
+	Filename string    `json:"filename"`
+	Source   string    `json:"source"`
+	AtStart  bool      `json:"at_start"`
+	At       time.Time `json:"at"`
+	Created  time.Time `json:"created"`
+}
+
+// When human readable time of the job
+func (j *QueueJob) When() string {
+	if j.AtStart {
+		return "contest starts"
+	}
+	return j.At.In(time.Local).Format("2006-01-02 15:04:05")
+}
+
+// Queue scheduled submissions
+type Queue struct {
+	NextID int        `json:"next_id"`
+	Jobs   []QueueJob `json:"jobs"`
+	path   string
+}
+
+// LoadQueue load the queue which is saved next to the session file
+func (c *Client) LoadQueue() (q *Queue, err error) {
+	q = &Queue{path: filepath.Join(filepath.Dir(c.path), "queue")}
+	err = q.Reload()
+	return
+}
+
+// lock acquire the lock file of the queue, so that cf running in another
+// terminal does not overwrite the changes. Call unlock to release it
+func (q *Queue) lock() (unlock func(), err error) {
+	path := q.path + ".lock"
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		// The lock is only held for a moment, so it's left by a crashed cf
+		if info, e := os.Stat(path); e == nil && time.Since(info.ModTime()) > 10*time.Second {
+			os.Remove(path)
+			continue
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// load read the queue from the file. The caller should hold the lock
+func (q *Queue) load() (err error) {
+	q.NextID, q.Jobs = 1, nil
+	data, err := ioutil.ReadFile(q.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	return json.Unmarshal(data, q)
+}
+
+func (q *Queue) save() (err error) {
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err == nil {
+		os.MkdirAll(filepath.Dir(q.path), os.ModePerm)
+		err = ioutil.WriteFile(q.path, data, 0644)
+	}
+	return
+}
+
+// Reload read the latest queue, which may be changed by cf in another terminal
+func (q *Queue) Reload() (err error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return
+	}
+	defer unlock()
+	return q.load()
+}
+
+// Has whether the job with id is in the queue as last loaded
+func (q *Queue) Has(id int) bool {
+	for _, job := range q.Jobs {
+		if job.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Add add a job to the latest queue and save it
+func (q *Queue) Add(job QueueJob) (QueueJob, error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return job, err
+	}
+	defer unlock()
+	if err = q.load(); err != nil {
+		return job, err
+	}
+	job.ID = q.NextID
+	job.Created = time.Now()
+	q.NextID++
+	q.Jobs = append(q.Jobs, job)
+	return job, q.save()
+}
+
+// Remove remove the job with id from the latest queue and save it
+func (q *Queue) Remove(id int) error {
+	unlock, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err = q.load(); err != nil {
+		return err
+	}
+	for i, job := range q.Jobs {
+		if job.ID == id {
+			q.Jobs = append(q.Jobs[:i], q.Jobs[i+1:]...)
+			return q.save()
+		}
+	}
+	return fmt.Errorf("Cannot find job #%v", id)
+}
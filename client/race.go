@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"regexp"
 	"strconv"
 	"time"
 
-	"github.com/xalanq/cf-tool/util"
-
 	"github.com/fatih/color"
 	ansi "github.com/k0kubun/go-ansi"
 )
@@ -26,35 +26,57 @@ func findCountdown(body []byte) (int, error) {
 	return h*60*60 + m*60 + s, nil
 }
 
-// RaceContest wait for contest starting
-func (c *Client) RaceContest(info Info) (err error) {
-	color.Cyan("Race " + info.Hint())
-
+// Countdown return the seconds before the contest starts (0 if it has started)
+// and the server clock read from the countdown page
+func (c *Client) Countdown(info Info) (count int, serverTime time.Time, err error) {
 	URL, err := info.ProblemSetURL(c.host)
 	if err != nil {
 		return
 	}
 	if info.ProblemType == "acmsguru" {
-		return errors.New(ErrorNotSupportAcmsguru)
+		return 0, serverTime, errors.New(ErrorNotSupportAcmsguru)
 	}
 
-	URL = URL + "/countdown"
-
-	body, err := util.GetBody(c.client, URL)
+	resp, err := c.client.Get(URL + "/countdown")
 	if err != nil {
 		return
 	}
-
-	_, err = findHandle(body)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return
 	}
+	serverTime = time.Now()
+	if date, e := http.ParseTime(resp.Header.Get("Date")); e == nil {
+		serverTime = date
+	}
+
+	if _, err = findHandle(body); err != nil {
+		return
+	}
 
 	if !bytes.Contains(body, []byte(`Go!</a>`)) {
-		count, err := findCountdown(body)
-		if err != nil {
-			return err
-		}
+		count, err = findCountdown(body)
+	}
+	return
+}
+
+// RaceContest wait for contest starting
+func (c *Client) RaceContest(info Info) (err error) {
+	color.Cyan("Race " + info.Hint())
+
+	count, _, err := c.Countdown(info)
+	if err != nil {
+		return
+	}
+
+	countdown(count)
+	return
+}
+
+// countdown print the countdown of count seconds and block until it ends
+func countdown(count int) {
+	if count > 0 {
 		color.Green("Countdown: ")
 		for count > 0 {
 			h := count / 60 / 60
@@ -67,6 +89,4 @@ func (c *Client) RaceContest(info Info) (err error) {
 		}
 		time.Sleep(900 * time.Millisecond)
 	}
-
-	return
 }
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+)
+
+// APIUser user object of codeforces api
+type APIUser struct {
+	Handle    string `json:"handle"`
+	Rating    int    `json:"rating"`
+	MaxRating int    `json:"maxRating"`
+	Rank      string `json:"rank"`
+	MaxRank   string `json:"maxRank"`
+}
+
+// APIRatingChange rating change object of codeforces api
+type APIRatingChange struct {
+	ContestID               int    `json:"contestId"`
+	ContestName             string `json:"contestName"`
+	Handle                  string `json:"handle"`
+	Rank                    int    `json:"rank"`
+	RatingUpdateTimeSeconds int64  `json:"ratingUpdateTimeSeconds"`
+	OldRating               int    `json:"oldRating"`
+	NewRating               int    `json:"newRating"`
+}
+
+// UserInfo information of the handle
+func (c *Client) UserInfo(handle string) (user APIUser, err error) {
+	users := []APIUser{}
+	if err = c.api("user.info", url.Values{"handles": {handle}}, &users); err != nil {
+		return
+	}
+	if len(users) == 0 {
+		return user, errors.New("Cannot find the user")
+	}
+	return users[0], nil
+}
+
+// UserRating rating changes of the handle, in order of time
+func (c *Client) UserRating(handle string) (changes []APIRatingChange, err error) {
+	err = c.api("user.rating", url.Values{"handle": {handle}}, &changes)
+	return
+}
+
+// RatingChanges rating changes of the contest, which are available after
+// system testing
+func (c *Client) RatingChanges(info Info) (changes []APIRatingChange, err error) {
+	if info.ContestID == "" {
+		_, err = info.errorContest()
+		return
+	}
+	err = c.api("contest.ratingChanges", url.Values{"contestId": {info.ContestID}}, &changes)
+	return
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/xalanq/cf-tool/util"
@@ -18,6 +19,46 @@ type StatisInfo struct {
 	State  string
 }
 
+// ProblemStats structured statis of a problem
+type ProblemStats struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Passed      int    `json:"passed"`
+	Accepted    bool   `json:"accepted"`
+	Rejected    bool   `json:"rejected"`
+	Untouched   bool   `json:"untouched"`
+	TimeLimit   int    `json:"time_limit_ms"`
+	MemoryLimit int    `json:"memory_limit_mb"`
+	Input       string `json:"input"`
+	Output      string `json:"output"`
+}
+
+// Stats parse the state, the limit and the IO of the problem. E.g. "2 s, 256
+// MB" and "standard input/output"
+func (s *StatisInfo) Stats() ProblemStats {
+	stats := ProblemStats{ID: s.ID, Name: s.Name}
+	stats.Passed, _ = strconv.Atoi(s.Passed)
+	stats.Accepted = strings.Contains(s.State, "accepted")
+	stats.Rejected = !stats.Accepted && strings.Contains(s.State, "rejected")
+	stats.Untouched = !stats.Accepted && !stats.Rejected
+	if tmp := regexp.MustCompile(`([\d.]+)\s*s`).FindStringSubmatch(s.Limit); tmp != nil {
+		t, _ := strconv.ParseFloat(tmp[1], 64)
+		stats.TimeLimit = int(t*1000 + 0.5)
+	}
+	if tmp := regexp.MustCompile(`(\d+)\s*MB`).FindStringSubmatch(s.Limit); tmp != nil {
+		stats.MemoryLimit, _ = strconv.Atoi(tmp[1])
+	}
+	io := strings.TrimSpace(s.IO)
+	if io == "standard input/output" {
+		stats.Input, stats.Output = "standard input", "standard output"
+	} else if p := strings.Index(io, "/"); p != -1 {
+		stats.Input, stats.Output = strings.TrimSpace(io[:p]), strings.TrimSpace(io[p+1:])
+	} else {
+		stats.Input, stats.Output = io, io
+	}
+	return stats
+}
+
 func findStatisBlock(body []byte) ([]byte, error) {
 	reg := regexp.MustCompile(`class="problems"[\s\S]+?</tr>([\s\S]+?)</table>`)
 	tmp := reg.FindSubmatch(body)
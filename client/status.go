@@ -0,0 +1,146 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xalanq/cf-tool/util"
+)
+
+// verdictText verdicts of codeforces api as shown on the website
+var verdictText = map[string]string{
+	"FAILED":                    "${c-failed}Failed",
+	"OK":                        "${c-accepted}Accepted",
+	"PARTIAL":                   "${c-rejected}Partial result",
+	"COMPILATION_ERROR":         "${c-rejected}Compilation error",
+	"RUNTIME_ERROR":             "${c-rejected}Runtime error",
+	"WRONG_ANSWER":              "${c-rejected}Wrong answer",
+	"PRESENTATION_ERROR":        "${c-rejected}Presentation error",
+	"TIME_LIMIT_EXCEEDED":       "${c-rejected}Time limit exceeded",
+	"MEMORY_LIMIT_EXCEEDED":     "${c-rejected}Memory limit exceeded",
+	"IDLENESS_LIMIT_EXCEEDED":   "${c-rejected}Idleness limit exceeded",
+	"SECURITY_VIOLATED":         "${c-rejected}Security violated",
+	"CRASHED":                   "${c-failed}Denial of judgement",
+	"INPUT_PREPARATION_CRASHED": "${c-failed}Input preparation failed",
+	"CHALLENGED":                "${c-rejected}Hacked",
+	"SKIPPED":                   "${c-rejected}Skipped",
+	"TESTING":                   "${c-waiting}Running",
+	"REJECTED":                  "${c-rejected}Rejected",
+	"SUBMITTED":                 "${c-waiting}In queue",
+}
+
+// toSubmission convert a submission of codeforces api to the one shown by watch
+func (s *APISubmission) toSubmission() Submission {
+	verdict := s.Verdict
+	if verdict == "" {
+		verdict = "SUBMITTED"
+	}
+	status, ok := verdictText[verdict]
+	if !ok {
+		status = "${c-rejected}" + verdict
+	}
+	passed := uint64(s.PassedTestCount)
+	test := uint64(0)
+	if _, ok := verdictAbbr[verdict]; ok || verdict == "TESTING" {
+		test = passed + 1
+		status = fmt.Sprintf("%v on test %v", status, test)
+	} else if verdict == "OK" && s.Testset == "PRETESTS" {
+		status = "${c-accepted}Pretests passed"
+	} else if verdict == "PARTIAL" {
+		status = fmt.Sprintf("%v: %v points", status, s.Points)
+	}
+	return Submission{
+		name:    fmt.Sprintf("%v - %v", s.Problem.Index, s.Problem.Name),
+		id:      uint64(s.ID),
+		status:  status,
+		verdict: verdict,
+		test:    test,
+		passed:  passed,
+		judged:  test,
+		points:  uint64(s.Points),
+		time:    uint64(s.TimeConsumedMillis),
+		memory:  uint64(s.MemoryConsumedBytes),
+		lang:    s.ProgrammingLanguage,
+		when:    time.Unix(s.CreationTimeSeconds, 0).In(time.Local).Format("2006-01-02 15:04"),
+		handle:  s.Author.Name(),
+		end:     !isWait(verdict),
+	}
+}
+
+// ContestStatus submissions of handle in the contest. n is the number of
+// submissions, -1 means all
+func (c *Client) ContestStatus(info Info, handle string, n int) (submissions []APISubmission, err error) {
+	if info.ContestID == "" {
+		_, err = info.errorContest()
+		return
+	}
+	if info.ProblemType == "group" || info.ProblemType == "acmsguru" {
+		return nil, fmt.Errorf("Not support watching others in %v", info.ProblemType)
+	}
+	query := url.Values{"contestId": {info.ContestID}, "handle": {handle}}
+	if n > 0 {
+		query.Set("from", "1")
+		query.Set("count", fmt.Sprint(n))
+	}
+	err = c.api("contest.status", query, &submissions)
+	return
+}
+
+// getHandlesSubmissions merge the first n submissions of all handles
+func (c *Client) getHandlesSubmissions(info Info, handles []string, n int) (submissions []Submission, err error) {
+	seen := map[int64]bool{}
+	for _, handle := range handles {
+		result, err := c.ContestStatus(info, handle, n)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", handle, err.Error())
+		}
+		for _, s := range result {
+			// A submission of a team appears in the status of every member
+			if !seen[s.ID] {
+				seen[s.ID] = true
+				submissions = append(submissions, s.toSubmission())
+			}
+		}
+	}
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].id > submissions[j].id
+	})
+	if n > 0 && len(submissions) > n {
+		submissions = submissions[:n]
+	}
+	if len(submissions) < 1 {
+		return nil, errors.New("Cannot find any submission")
+	}
+	return
+}
+
+// Friends handles of your friends
+func (c *Client) Friends() (handles []string, err error) {
+	body, err := util.GetBody(c.client, c.host+"/friends")
+	if err != nil {
+		return
+	}
+
+	if _, err = findHandle(body); err != nil {
+		return
+	}
+
+	reg := regexp.MustCompile(`<a href="/profile/([^"]+?)"[^>]*class="rated-user`)
+	seen := map[string]bool{}
+	for _, tmp := range reg.FindAllSubmatch(body, -1) {
+		handle := string(tmp[1])
+		if !seen[handle] && !strings.EqualFold(handle, c.Handle) {
+			seen[handle] = true
+			handles = append(handles, handle)
+		}
+	}
+	if len(handles) == 0 {
+		return nil, errors.New("Cannot find any friend")
+	}
+	return
+}
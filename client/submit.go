@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/url"
@@ -21,8 +22,12 @@ func findErrorMessage(body []byte) (string, error) {
 	return string(tmp[1]), nil
 }
 
-// Submit submit (block while pending)
-func (c *Client) Submit(info Info, langID, source string) (err error) {
+// ErrorNotInPractice error. Only contests of codeforces fall back to the
+// problemset, gym and group contests have no other place to submit
+const ErrorNotInPractice = "Cannot find the submit form. The contest is not open for practice. Gym and group contests cannot be submitted to the problemset"
+
+// Submit submit (block while pending). Return the judged submission
+func (c *Client) Submit(info Info, langID, source string) (submission Submission, err error) {
 	color.Cyan("Submit " + info.Hint())
 
 	URL, err := info.SubmitURL(c.host)
@@ -47,45 +52,70 @@ func (c *Client) Submit(info Info, langID, source string) (err error) {
 		return
 	}
 
-	body, err = util.PostBody(c.client, fmt.Sprintf("%v?csrf_token=%v", URL, csrf), url.Values{
-		"csrf_token":            {csrf},
-		"ftaa":                  {c.Ftaa},
-		"bfaa":                  {c.Bfaa},
-		"action":                {"submitSolutionFormSubmitted"},
-		"submittedProblemIndex": {info.ProblemID},
-		"programTypeId":         {langID},
-		"contestId":             {info.ContestID},
-		"source":                {source},
-		"tabSize":               {"4"},
-		"_tta":                  {"594"},
-		"sourceCodeConfirmed":   {"true"},
-	})
+	if !bytes.Contains(body, []byte(`name="submittedProblemIndex"`)) && !bytes.Contains(body, []byte(`name="submittedProblemCode"`)) {
+		if info.ProblemType == "contest" {
+			color.Yellow("The contest does not accept submissions. Submit to the problemset")
+			info.ProblemType = "problemset"
+			return c.Submit(info, langID, source)
+		}
+		return submission, errors.New(ErrorNotInPractice)
+	}
+
+	data := url.Values{
+		"csrf_token":          {csrf},
+		"ftaa":                {c.Ftaa},
+		"bfaa":                {c.Bfaa},
+		"action":              {"submitSolutionFormSubmitted"},
+		"programTypeId":       {langID},
+		"source":              {source},
+		"tabSize":             {"4"},
+		"_tta":                {"594"},
+		"sourceCodeConfirmed": {"true"},
+	}
+	if info.ProblemType == "problemset" {
+		data.Set("submittedProblemCode", info.ContestID+strings.ToUpper(info.ProblemID))
+	} else {
+		data.Set("submittedProblemIndex", info.ProblemID)
+		data.Set("contestId", info.ContestID)
+	}
+	body, err = util.PostBody(c.client, fmt.Sprintf("%v?csrf_token=%v", URL, csrf), data)
 	if err != nil {
 		return
 	}
 
 	errMsg, err := findErrorMessage(body)
 	if err == nil {
-		return errors.New(errMsg)
+		return submission, errors.New(errMsg)
 	}
 
 	msg, err := findMessage(body)
 	if err != nil {
-		return errors.New("Submit failed")
+		return submission, errors.New("Submit failed")
 	}
 	if !strings.Contains(msg, "submitted successfully") {
-		return errors.New(msg)
+		return submission, errors.New(msg)
 	}
 
 	color.Green("Submitted")
+	id, e := c.addHistory(info, langID, source)
+	if e != nil {
+		color.Red("Cannot save history: %v", e.Error())
+	}
+	c.recordSubmitted(info, source, id)
+	c.save()
 
 	submissions, err := c.WatchSubmission(info, 1, true)
 	if err != nil {
 		return
 	}
 
-	info.SubmissionID = submissions[0].ParseID()
+	c.updateSubmitted(info, submissions[0])
+	if e == nil {
+		c.judgeHistory(id, submissions[0])
+	}
+	submission = submissions[0]
+	info.SubmissionID = submission.ParseID()
 	c.Handle = handle
 	c.LastSubmission = &info
-	return c.save()
+	return submission, c.save()
 }
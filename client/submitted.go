@@ -0,0 +1,85 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// SubmittedSource a source which has been submitted to a problem. HistoryID
+// is the id of its record in the history, empty for older submissions
+type SubmittedSource struct {
+	Hash         string    `json:"hash"`
+	SubmissionID string    `json:"submission_id"`
+	Verdict      string    `json:"verdict"`
+	When         time.Time `json:"when"`
+	HistoryID    string    `json:"history_id,omitempty"`
+}
+
+// contestKey identify a contest. A problem of the problemset belongs to the
+// contest with the same id
+func contestKey(info Info) string {
+	problemType := info.ProblemType
+	if problemType == "problemset" {
+		problemType = "contest"
+	}
+	return strings.ToLower(strings.Join([]string{problemType, info.GroupID, info.ContestID}, "/"))
+}
+
+// problemKey identify a problem regardless of the case of problem id
+func problemKey(info Info) string {
+	return contestKey(info) + "/" + strings.ToLower(info.ProblemID)
+}
+
+// hashSource hash of the source modulo whitespace
+func hashSource(source string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(source), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// FindSubmitted return the last submission of the same source to the same
+// problem, nil if there is none
+func (c *Client) FindSubmitted(info Info, source string) *SubmittedSource {
+	hash := hashSource(source)
+	records := c.Submitted[problemKey(info)]
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Hash == hash {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// LastSubmitted return the last submission to the problem, nil if there is none
+func (c *Client) LastSubmitted(info Info) *SubmittedSource {
+	records := c.Submitted[problemKey(info)]
+	if len(records) == 0 {
+		return nil
+	}
+	return &records[len(records)-1]
+}
+
+// recordSubmitted remember the source right after it is submitted
+func (c *Client) recordSubmitted(info Info, source, historyID string) {
+	if c.Submitted == nil {
+		c.Submitted = map[string][]SubmittedSource{}
+	}
+	key := problemKey(info)
+	c.Submitted[key] = append(c.Submitted[key], SubmittedSource{
+		Hash:      hashSource(source),
+		Verdict:   "Unknown",
+		When:      time.Now(),
+		HistoryID: historyID,
+	})
+}
+
+// updateSubmitted fill the last record of the problem with the judged submission
+func (c *Client) updateSubmitted(info Info, submission Submission) {
+	records := c.Submitted[problemKey(info)]
+	if len(records) == 0 {
+		return
+	}
+	records[len(records)-1].SubmissionID = submission.ParseID()
+	records[len(records)-1].Verdict = submission.PlainStatus()
+}
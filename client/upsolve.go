@@ -0,0 +1,109 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// UpsolveProblem unsolved problem of a contest which we took part in
+type UpsolveProblem struct {
+	ProblemsetInfo
+	ContestName string
+}
+
+// upsolveState contests taken part in and problems solved, kept across runs
+// so that the list only shrinks
+type upsolveState struct {
+	Handle   string          `json:"handle"`
+	Contests map[int]string  `json:"contests"`
+	Solved   map[string]bool `json:"solved"`
+}
+
+func (c *Client) upsolvePath() string {
+	return filepath.Join(filepath.Dir(c.path), "upsolve")
+}
+
+func (c *Client) loadUpsolve(handle string) (state upsolveState) {
+	if data, err := ioutil.ReadFile(c.upsolvePath()); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	if !strings.EqualFold(state.Handle, handle) || state.Contests == nil || state.Solved == nil {
+		state = upsolveState{Handle: handle, Contests: map[int]string{}, Solved: map[string]bool{}}
+	}
+	return
+}
+
+func (c *Client) saveUpsolve(state upsolveState) (err error) {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(c.upsolvePath()), os.ModePerm)
+	return ioutil.WriteFile(c.upsolvePath(), data, 0644)
+}
+
+// Upsolve problems of the contests which handle took part in, either rated
+// or not, and has not solved yet. Problems accepted by cf count as solved
+// even before the api knows them. Sorted by rating, then by solved count
+func (c *Client) Upsolve(handle string) (problems []UpsolveProblem, err error) {
+	state := c.loadUpsolve(handle)
+
+	color.Cyan("Fetch contests of %v", handle)
+	changes, err := c.UserRating(handle)
+	if err != nil {
+		return
+	}
+	for _, change := range changes {
+		state.Contests[change.ContestID] = change.ContestName
+	}
+	submissions, err := c.UserStatus(handle)
+	if err != nil {
+		return
+	}
+	for _, s := range submissions {
+		if s.Verdict == "OK" {
+			state.Solved[s.Problem.ID()] = true
+		}
+		if _, ok := state.Contests[s.ContestID]; !ok && s.Author.ParticipantType != "PRACTICE" {
+			state.Contests[s.ContestID] = ""
+		}
+	}
+	if records, err := c.LoadHistory(); err == nil {
+		for _, r := range records {
+			if strings.HasPrefix(r.Verdict, "Accepted") &&
+				(r.Info.ProblemType == "contest" || r.Info.ProblemType == "problemset") {
+				state.Solved[r.Info.ContestID+strings.ToUpper(r.Info.ProblemID)] = true
+			}
+		}
+	}
+	if err = c.saveUpsolve(state); err != nil {
+		return
+	}
+
+	all, err := c.Problemset(nil)
+	if err != nil {
+		return
+	}
+	for _, prob := range all {
+		name, ok := state.Contests[prob.ContestID]
+		if !ok || state.Solved[prob.ID()] {
+			continue
+		}
+		problems = append(problems, UpsolveProblem{prob, name})
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if a.Rating != b.Rating {
+			// Problems without rating go last
+			return b.Rating == 0 || (a.Rating != 0 && a.Rating < b.Rating)
+		}
+		return a.SolvedCount > b.SolvedCount
+	})
+	return
+}
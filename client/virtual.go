@@ -0,0 +1,161 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// VirtualContest a virtual participation registered by cf
+type VirtualContest struct {
+	Info  Info      `json:"info"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// JustEnded whether the virtual contest has ended within the length of the
+// contest. Later submissions are upsolving
+func (v *VirtualContest) JustEnded(now time.Time) bool {
+	return !now.Before(v.End) && now.Before(v.End.Add(v.End.Sub(v.Start)))
+}
+
+func (c *Client) virtualPath() string {
+	return filepath.Join(filepath.Dir(c.path), "virtual")
+}
+
+// LoadVirtuals all virtual participations registered by cf
+func (c *Client) LoadVirtuals() (virtuals []VirtualContest, err error) {
+	data, err := ioutil.ReadFile(c.virtualPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	err = json.Unmarshal(data, &virtuals)
+	return
+}
+
+// RaceVirtual wait for the virtual contest starting
+func (c *Client) RaceVirtual(virtual VirtualContest) {
+	color.Cyan("Race the virtual contest of " + virtual.Info.Hint())
+	countdown(int(time.Until(virtual.Start).Seconds()))
+}
+
+// FindVirtual return the last virtual participation of the contest, nil if
+// there is none. The contest may be given by any type, e.g. "problemset"
+func (c *Client) FindVirtual(info Info) *VirtualContest {
+	virtuals, err := c.LoadVirtuals()
+	if err != nil {
+		return nil
+	}
+	for i := len(virtuals) - 1; i >= 0; i-- {
+		v := virtuals[i]
+		if contestKey(v.Info) == contestKey(info) {
+			return &virtuals[i]
+		}
+	}
+	return nil
+}
+
+func (c *Client) saveVirtual(virtual VirtualContest) (err error) {
+	virtuals, err := c.LoadVirtuals()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(append(virtuals, virtual), "", "  ")
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(c.virtualPath()), os.ModePerm)
+	return ioutil.WriteFile(c.virtualPath(), data, 0644)
+}
+
+// parseOffset parse the utc offset of codeforces, e.g. "+03:00"
+func parseOffset(offset string) *time.Location {
+	t, err := time.Parse("-07:00", offset)
+	if err != nil {
+		return time.UTC
+	}
+	_, sec := t.Zone()
+	return time.FixedZone(offset, sec)
+}
+
+// RegisterVirtual register a virtual participation starting at start by the
+// web form, and save it with the end time of the contest
+func (c *Client) RegisterVirtual(info Info, start time.Time) (virtual VirtualContest, err error) {
+	var URL string
+	switch info.ProblemType {
+	case "contest":
+		URL = fmt.Sprintf("%v/contestRegistration/%v/virtual/true", c.host, info.ContestID)
+	case "gym":
+		URL = fmt.Sprintf("%v/gymRegistration/%v/virtual/true", c.host, info.ContestID)
+	default:
+		return virtual, fmt.Errorf("Not support virtual participation in %v", info.ProblemType)
+	}
+	if info.ContestID == "" {
+		_, err = info.errorContest()
+		return
+	}
+
+	standings, err := c.ContestStandings(info, url.Values{"from": {"1"}, "count": {"1"}})
+	if err != nil {
+		return
+	}
+	duration := time.Duration(standings.Contest.DurationSeconds) * time.Second
+
+	color.Cyan("Register a virtual participation of %v", standings.Contest.Name)
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	if _, err = findHandle(body); err != nil {
+		return
+	}
+
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+
+	// The form takes the time of codeforces in minutes
+	start = start.Truncate(time.Minute)
+	loc := time.UTC
+	if cfOffset, err := findCfOffset(body); err == nil {
+		loc = parseOffset(cfOffset)
+	}
+	t := start.In(loc)
+	body, err = util.PostBody(c.client, fmt.Sprintf("%v?csrf_token=%v", URL, csrf), url.Values{
+		"csrf_token": {csrf},
+		"action":     {"formSubmitted"},
+		"takePartAs": {"personal"},
+		"startDay":   {t.Format("Jan/02/2006")},
+		"startTime":  {t.Format("15:04")},
+	})
+	if err != nil {
+		return
+	}
+
+	if errMsg, e := findErrorMessage(body); e == nil {
+		return virtual, errors.New(errMsg)
+	}
+
+	virtual = VirtualContest{Info: info, Start: start, End: start.Add(duration)}
+	virtual.Info.ProblemID = ""
+	virtual.Info.RootPath = ""
+	if err = c.saveVirtual(virtual); err != nil {
+		return
+	}
+	color.Green("Registered. It starts at %v and ends at %v",
+		start.In(time.Local).Format("2006-01-02 15:04"), virtual.End.In(time.Local).Format("2006-01-02 15:04"))
+	return
+}
@@ -3,6 +3,8 @@ package client
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -21,28 +23,68 @@ import (
 
 // Submission submit state
 type Submission struct {
-	name   string
-	id     uint64
-	status string
-	passed uint64
-	judged uint64
-	points uint64
-	time   uint64
-	memory uint64
-	lang   string
-	when   string
-	end    bool
+	name    string
+	id      uint64
+	status  string
+	verdict string
+	test    uint64
+	passed  uint64
+	judged  uint64
+	points  uint64
+	time    uint64
+	memory  uint64
+	lang    string
+	when    string
+	handle  string
+	end     bool
 }
 
 func isWait(verdict string) bool {
-	return verdict == "null" || verdict == "TESTING" || verdict == "SUBMITTED"
+	return verdict == "" || verdict == "null" || verdict == "TESTING" || verdict == "SUBMITTED"
 }
 
-// ParseStatus with color
-func (s *Submission) ParseStatus() string {
+// verdictAbbr short names of verdicts which fail on a test
+var verdictAbbr = map[string]string{
+	"WRONG_ANSWER":            "WA",
+	"PRESENTATION_ERROR":      "PE",
+	"TIME_LIMIT_EXCEEDED":     "TLE",
+	"MEMORY_LIMIT_EXCEEDED":   "MLE",
+	"IDLENESS_LIMIT_EXCEEDED": "ILE",
+	"RUNTIME_ERROR":           "RE",
+	"SECURITY_VIOLATED":       "Security violated",
+	"CRASHED":                 "Crashed",
+	"CHALLENGED":              "Hacked",
+}
+
+var spinner = []string{"|", "/", "-", "\\"}
+
+func (s *Submission) fillStatus() string {
 	status := strings.ReplaceAll(s.status, "${f-points}", fmt.Sprintf("%v", s.points))
 	status = strings.ReplaceAll(status, "${f-passed}", fmt.Sprintf("%v", s.passed))
-	status = strings.ReplaceAll(status, "${f-judged}", fmt.Sprintf("%v", s.judged))
+	return strings.ReplaceAll(status, "${f-judged}", fmt.Sprintf("%v", s.judged))
+}
+
+// shortStatus e.g. "WA on test 7" or "Running on test 3 (2 passed)"
+func (s *Submission) shortStatus() string {
+	key := ""
+	for k := range colorMap {
+		if strings.HasPrefix(s.status, k) {
+			key = k
+		}
+	}
+	if !s.end && s.test > 0 {
+		frame := spinner[time.Now().Unix()%int64(len(spinner))]
+		return fmt.Sprintf("%vRunning on test %v (%v passed) %v", key, s.test, s.passed, frame)
+	}
+	if abbr, ok := verdictAbbr[s.verdict]; ok && s.test > 0 {
+		return fmt.Sprintf("%v%v on test %v", key, abbr, s.test)
+	}
+	return s.fillStatus()
+}
+
+// ParseStatus with color
+func (s *Submission) ParseStatus() string {
+	status := s.shortStatus()
 	for k, v := range colorMap {
 		tmp := strings.ReplaceAll(status, k, "")
 		if tmp != status {
@@ -52,6 +94,15 @@ func (s *Submission) ParseStatus() string {
 	return status
 }
 
+// PlainStatus without color
+func (s *Submission) PlainStatus() string {
+	status := s.fillStatus()
+	for k := range colorMap {
+		status = strings.ReplaceAll(status, k, "")
+	}
+	return status
+}
+
 // ParseID formatter
 func (s *Submission) ParseID() string {
 	return fmt.Sprintf("%v", s.id)
@@ -102,7 +153,7 @@ func (s *Submission) display(first bool, maxWidth *int) {
 	ansi.Printf("   prob: %v\n", s.name)
 	ansi.Printf("   lang: %v\n", s.lang)
 	refreshLine(1, *maxWidth)
-	ansi.Printf(updateLine(fmt.Sprintf(" status: %v\n", s.ParseStatus()), maxWidth))
+	ansi.Print(updateLine(fmt.Sprintf(" status: %v\n", s.ParseStatus()), maxWidth))
 	ansi.Printf("   time: %v\n", s.ParseTime())
 	ansi.Printf(" memory: %v\n", s.ParseMemory())
 }
@@ -115,7 +166,16 @@ func display(submissions []Submission, problemID string, first bool, maxWidth *i
 	var buf bytes.Buffer
 	output := io.Writer(&buf)
 	table := tablewriter.NewWriter(output)
-	table.SetHeader([]string{"#", "when", "problem", "lang", "status", "time", "memory"})
+	// Show who submitted if watching others
+	who := false
+	for _, sub := range submissions {
+		who = who || sub.handle != ""
+	}
+	header := []string{"#", "when", "problem", "lang", "status", "time", "memory"}
+	if who {
+		header = append([]string{"who"}, header...)
+	}
+	table.SetHeader(header)
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetAlignment(tablewriter.ALIGN_CENTER)
 	table.SetCenterSeparator("|")
@@ -124,7 +184,7 @@ func display(submissions []Submission, problemID string, first bool, maxWidth *i
 		if problemID != "" && sub.ParseProblemIndex() != problemID {
 			continue
 		}
-		table.Append([]string{
+		row := []string{
 			sub.ParseID(),
 			sub.when,
 			sub.name,
@@ -132,7 +192,11 @@ func display(submissions []Submission, problemID string, first bool, maxWidth *i
 			sub.ParseStatus(),
 			sub.ParseTime(),
 			sub.ParseMemory(),
-		})
+		}
+		if who {
+			row = append([]string{sub.handle}, row...)
+		}
+		table.Append(row)
 	}
 	table.Render()
 
@@ -193,19 +257,16 @@ func parseSubmission(body []byte, cfOffset string) (ret Submission, err error) {
 	}
 	reg := regexp.MustCompile(`\d+`)
 	getInt := func(sel string) uint64 {
-		if tmp := reg.FindString(doc.Find(sel).Text()); tmp != "" {
-			t, _ := strconv.Atoi(tmp)
-			return uint64(t)
-		}
-		return 0
+		return getIntOf(reg, doc.Find(sel).Text())
 	}
 	sub := doc.Find(".submissionVerdictWrapper")
-	end := false
-	if verdict, exist := sub.Attr("submissionverdict"); exist && !isWait(verdict) {
-		end = true
+	verdict, _ := sub.Attr("submissionverdict")
+	end := !isWait(verdict)
+	getFormat := func(name string) uint64 {
+		return getIntOf(reg, sub.Find(".verdict-format-"+name).First().Text())
 	}
+	judged, passed, points := getFormat("judged"), getFormat("passed"), getFormat("points")
 	status, _ := sub.Html()
-	numReg := regexp.MustCompile(`\d+`)
 	fmtReg := regexp.MustCompile(`<span\sclass=["']?verdict-format-([\S^>]+?)["']?>`)
 	colReg := regexp.MustCompile(`<span\sclass=["']?verdict-([\S^>]+?)["']?>`)
 	tagReg := regexp.MustCompile(`<[\s\S]*?>`)
@@ -222,27 +283,47 @@ func parseSubmission(body []byte, cfOffset string) (ret Submission, err error) {
 	if status == "" {
 		status = "Unknown"
 	}
-	var num uint64
-	if s := numReg.FindString(status); s != "" {
-		n, _ := strconv.Atoi(s)
-		num = uint64(n)
+	// "Wrong answer on test 7" and "Running on test 7" both mean that 6 tests
+	// have passed
+	test := judged
+	if verdict == "OK" || verdict == "PARTIAL" {
+		test = 0
+	}
+	if passed == 0 && test > 0 {
+		passed = test - 1
 	}
 	return Submission{
-		id:     getInt(".id-cell"),
-		name:   get("td[data-problemId]"),
-		lang:   get("td:not([class])"),
-		status: status,
-		time:   getInt(".time-consumed-cell"),
-		memory: getInt(".memory-consumed-cell") * 1024,
-		when:   when,
-		passed: num,
-		judged: num,
-		points: num,
-		end:    end,
+		id:      getInt(".id-cell"),
+		name:    get("td[data-problemId]"),
+		lang:    get("td:not([class])"),
+		status:  status,
+		verdict: verdict,
+		test:    test,
+		passed:  passed,
+		judged:  judged,
+		points:  points,
+		time:    getInt(".time-consumed-cell"),
+		memory:  getInt(".memory-consumed-cell") * 1024,
+		when:    when,
+		end:     end,
 	}, nil
 }
 
+func getIntOf(reg *regexp.Regexp, text string) uint64 {
+	if tmp := reg.FindString(text); tmp != "" {
+		t, _ := strconv.Atoi(tmp)
+		return uint64(t)
+	}
+	return 0
+}
+
 func (c *Client) getSubmissions(URL string, n int) (submissions []Submission, err error) {
+	submissions, _, err = c.getSubmissionsPage(URL, n)
+	return
+}
+
+// getSubmissionsPage submissions and the push channel of the page
+func (c *Client) getSubmissionsPage(URL string, n int) (submissions []Submission, channel string, err error) {
 	body, err := util.GetBody(c.client, URL)
 	if err != nil {
 		return
@@ -269,42 +350,166 @@ func (c *Client) getSubmissions(URL string, n int) (submissions []Submission, er
 	}
 
 	if len(submissions) < 1 {
-		return nil, errors.New("Cannot find any submission")
+		return nil, "", errors.New("Cannot find any submission")
 	}
 
+	channel = findPushChannel(body)
 	return
 }
 
-// WatchSubmission n is the number of submissions
-func (c *Client) WatchSubmission(info Info, n int, line bool) (submissions []Submission, err error) {
+// Submissions your first n submissions of the contest
+func (c *Client) Submissions(info Info, n int) (submissions []Submission, err error) {
 	URL, err := info.MySubmissionURL(c.host)
 	if err != nil {
 		return
 	}
+	return c.getSubmissions(URL, n)
+}
+
+// WatchSubmission n is the number of submissions
+func (c *Client) WatchSubmission(info Info, n int, line bool) (submissions []Submission, err error) {
+	return c.WatchHandles(info, nil, n, line)
+}
 
+// WatchHandles watch submissions of handles in the contest. Empty handles
+// means your own submissions
+func (c *Client) WatchHandles(info Info, handles []string, n int, line bool) (submissions []Submission, err error) {
 	maxWidth := 0
+	return c.watchSubmission(info, handles, n, line, func(submissions []Submission, first bool) {
+		display(submissions, info.ProblemID, first, &maxWidth, line)
+	})
+}
+
+// WatchSubmissionJSON write one line of json to w whenever a submission
+// changes its state, without moving the cursor
+func (c *Client) WatchSubmissionJSON(info Info, handles []string, n int, w io.Writer) (submissions []Submission, err error) {
+	last := map[uint64]SubmissionState{}
+	encoder := json.NewEncoder(w)
+	return c.watchSubmission(info, handles, n, false, func(submissions []Submission, first bool) {
+		// The oldest submission comes first
+		for i := len(submissions) - 1; i >= 0; i-- {
+			if info.ProblemID != "" && submissions[i].ParseProblemIndex() != info.ProblemID {
+				continue
+			}
+			state := submissions[i].State(info)
+			if last[state.ID] == state {
+				continue
+			}
+			last[state.ID] = state
+			encoder.Encode(state)
+		}
+	})
+}
+
+// watchSubmission fetch submissions until all of them are judged. Your own
+// submissions are fetched again once codeforces pushes an update, or else by
+// polling with backoff. fresh means the first submission is just submitted
+func (c *Client) watchSubmission(info Info, handles []string, n int, fresh bool, show func(submissions []Submission, first bool)) (submissions []Submission, err error) {
+	fetch := func() ([]Submission, error) {
+		return c.getHandlesSubmissions(info, handles, n)
+	}
+	// Every handle takes one call of the api, which is throttled by c.api
+	minInterval := time.Duration(len(handles)) * apiInterval
+	maxInterval := time.Duration(len(handles)) * maxPollInterval
+	channel := ""
+	if len(handles) == 0 {
+		URL, err := info.MySubmissionURL(c.host)
+		if err != nil {
+			return nil, err
+		}
+		fetch = func() (submissions []Submission, err error) {
+			submissions, ch, err := c.getSubmissionsPage(URL, n)
+			if channel == "" {
+				channel = ch
+			}
+			return submissions, err
+		}
+		minInterval, maxInterval = minPollInterval, maxPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var notify <-chan struct{}
+	// reconnect the delay before subscribing again after the push channel is
+	// lost, which grows as long as the channel keeps dropping
+	reconnect := time.Duration(0)
+	retryAt := time.Time{}
+
+	clk := c.getClock()
 	first := true
+	pending := map[uint64]bool{}
+	last := map[uint64]SubmissionState{}
+	interval := minInterval
 	for {
-		st := time.Now()
-		submissions, err = c.getSubmissions(URL, n)
+		st := clk.Now()
+		submissions, err = fetch()
 		if err != nil {
 			return
 		}
-		display(submissions, info.ProblemID, first, &maxWidth, line)
-		first = false
+		show(submissions, first)
 		endCount := 0
-		for _, submission := range submissions {
+		changed := false
+		for i, submission := range submissions {
+			if state := submission.State(info); last[submission.id] != state {
+				last[submission.id] = state
+				changed = true
+			}
 			if submission.end {
 				endCount++
+				if pending[submission.id] || (first && fresh && i == 0) {
+					delete(pending, submission.id)
+					c.judged(info, submission)
+				}
+			} else {
+				pending[submission.id] = true
 			}
 		}
+		first = false
 		if endCount == len(submissions) {
 			return
 		}
-		sub := time.Now().Sub(st)
-		if sub < time.Second {
-			time.Sleep(time.Duration(time.Second - sub))
+
+		if notify == nil && channel != "" && !clk.Now().Before(retryAt) {
+			notify = c.subscribe(ctx, channel)
+		}
+		if notify != nil {
+			interval = backoff(interval, minInterval, pushPollInterval, changed)
+		} else {
+			interval = backoff(interval, minInterval, maxInterval, changed)
+		}
+		timer, stopTimer := clk.NewTimer(interval - clk.Now().Sub(st))
+		for done := false; !done; {
+			var retry <-chan time.Time
+			stopRetry := func() {}
+			if notify == nil && channel != "" {
+				retry, stopRetry = clk.NewTimer(retryAt.Sub(clk.Now()))
+			}
+			select {
+			case <-retry:
+				notify = c.subscribe(ctx, channel)
+			case _, ok := <-notify:
+				if ok {
+					reconnect = 0
+				} else {
+					// The push channel is lost, so poll until subscribing again
+					notify = nil
+					interval = minInterval
+					reconnect = backoff(reconnect, minPollInterval, pushPollInterval, false)
+					retryAt = clk.Now().Add(reconnect)
+				}
+				// Do not fetch more often than the minimum interval
+				if sub := clk.Now().Sub(st); sub < minInterval {
+					wait, stopWait := clk.NewTimer(minInterval - sub)
+					<-wait
+					stopWait()
+				}
+				done = true
+			case <-timer:
+				done = true
+			}
+			stopRetry()
 		}
+		stopTimer()
 	}
 }
 
@@ -13,28 +13,74 @@ import (
 
 // ParsedArgs parsed arguments
 type ParsedArgs struct {
-	Info      client.Info
-	File      string
-	Specifier []string `docopt:"<specifier>"`
-	Alias     string   `docopt:"<alias>"`
-	Accepted  bool     `docopt:"ac"`
-	All       bool     `docopt:"all"`
-	Handle    string   `docopt:"<handle>"`
-	Version   string   `docopt:"{version}"`
-	Config    bool     `docopt:"config"`
-	Submit    bool     `docopt:"submit"`
-	List      bool     `docopt:"list"`
-	Parse     bool     `docopt:"parse"`
-	Gen       bool     `docopt:"gen"`
-	Test      bool     `docopt:"test"`
-	Watch     bool     `docopt:"watch"`
-	Open      bool     `docopt:"open"`
-	Stand     bool     `docopt:"stand"`
-	Sid       bool     `docopt:"sid"`
-	Race      bool     `docopt:"race"`
-	Pull      bool     `docopt:"pull"`
-	Clone     bool     `docopt:"clone"`
-	Upgrade   bool     `docopt:"upgrade"`
+	Info       client.Info
+	File       string
+	Specifier  []string `docopt:"<specifier>"`
+	Alias      string   `docopt:"<alias>"`
+	Accepted   bool     `docopt:"ac"`
+	All        bool     `docopt:"all"`
+	Handle     string   `docopt:"<handle>"`
+	Version    string   `docopt:"{version}"`
+	Config     bool     `docopt:"config"`
+	Submit     bool     `docopt:"submit"`
+	List       bool     `docopt:"list"`
+	Parse      bool     `docopt:"parse"`
+	Gen        bool     `docopt:"gen"`
+	Test       bool     `docopt:"test"`
+	Watch      bool     `docopt:"watch"`
+	Open       bool     `docopt:"open"`
+	Stand      bool     `docopt:"stand"`
+	Sid        bool     `docopt:"sid"`
+	Race       bool     `docopt:"race"`
+	Pull       bool     `docopt:"pull"`
+	Clone      bool     `docopt:"clone"`
+	Upgrade    bool     `docopt:"upgrade"`
+	Problemset bool     `docopt:"problemset"`
+	Unsolved   bool     `docopt:"unsolved"`
+	Tags       string   `docopt:"--tags"`
+	Rating     string   `docopt:"--rating"`
+	Solved     string   `docopt:"--solved"`
+	Limit      int      `docopt:"--limit"`
+	TestFirst  bool     `docopt:"--test"`
+	Force      bool     `docopt:"--force"`
+	Late       bool     `docopt:"--late"`
+	At         string   `docopt:"--at"`
+	Queue      bool     `docopt:"queue"`
+	Ls         bool     `docopt:"ls"`
+	Cancel     bool     `docopt:"cancel"`
+	Run        bool     `docopt:"run"`
+	JobID      string   `docopt:"<job-id>"`
+	History    bool     `docopt:"history"`
+	Verdict    string   `docopt:"--verdict"`
+	Since      string   `docopt:"--since"`
+	Until      string   `docopt:"--until"`
+	Langs      bool     `docopt:"langs"`
+	Update     bool     `docopt:"update"`
+	JSON       bool     `docopt:"--json"`
+	Friends    bool     `docopt:"friends"`
+	Handles    string   `docopt:"--handles"`
+	Dash       bool     `docopt:"dash"`
+	Standings  bool     `docopt:"standings"`
+	Room       string   `docopt:"--room"`
+	Page       int      `docopt:"--page"`
+	Contests   bool     `docopt:"contests"`
+	Upcoming   bool     `docopt:"upcoming"`
+	Running    bool     `docopt:"running"`
+	Past       bool     `docopt:"past"`
+	Gym        bool     `docopt:"gym"`
+	ICS        string   `docopt:"--ics"`
+	Format     string   `docopt:"--format"`
+	Status     bool     `docopt:"status"`
+	ShowRating bool     `docopt:"rating"`
+	Changes    bool     `docopt:"changes"`
+	Virtual    bool     `docopt:"virtual"`
+	Upsolve    bool     `docopt:"upsolve"`
+	Hack       bool     `docopt:"hack"`
+	Submission string   `docopt:"<submission-id>"`
+	Input      string   `docopt:"--input"`
+	Generator  string   `docopt:"--gen"`
+	Checker    string   `docopt:"--checker"`
+	Tries      int      `docopt:"--tries"`
 }
 
 // Args global variable
@@ -151,12 +197,16 @@ const GroupRegStr = `\w{10}`
 // SubmissionRegStr submission
 const SubmissionRegStr = `\d+`
 
+// argKeywordCount the first argKeywordCount of ArgRegStr are problem types
+const argKeywordCount = 5
+
 // ArgRegStr for parsing arg
 var ArgRegStr = [...]string{
 	`^[cC][oO][nN][tT][eE][sS][tT][sS]?$`,
 	`^[gG][yY][mM][sS]?$`,
 	`^[gG][rR][oO][uU][pP][sS]?$`,
 	`^[aA][cC][mM][sS][gG][uU][rR][uU]$`,
+	`^[pP][rR][oO][bB][lL][eE][mM][sS][eE][tT]$`,
 	fmt.Sprintf(`/contest/(?P<contestID>%v)(/problem/(?P<problemID>%v))?`, ContestRegStr, ProblemRegStr),
 	fmt.Sprintf(`/gym/(?P<contestID>%v)(/problem/(?P<problemID>%v))?`, ContestRegStr, ProblemRegStr),
 	fmt.Sprintf(`/problemset/problem/(?P<contestID>%v)/(?P<problemID>%v)`, ContestRegStr, ProblemRegStr),
@@ -176,6 +226,7 @@ var ArgTypePathRegStr = [...]string{
 	fmt.Sprintf("%v/%v/((?P<contestID>%v)/((?P<problemID>%v)/)?)?", "%v", "%v", ContestRegStr, ProblemRegStr),
 	fmt.Sprintf("%v/%v/((?P<groupID>%v)/((?P<contestID>%v)/((?P<problemID>%v)/)?)?)?", "%v", "%v", GroupRegStr, ContestRegStr, ProblemRegStr),
 	fmt.Sprintf("%v/%v/((?P<problemID>%v)/)?", "%v", "%v", ProblemRegStr),
+	fmt.Sprintf("%v/%v/((?P<contestID>%v)/((?P<problemID>%v)/)?)?", "%v", "%v", ContestRegStr, ProblemRegStr),
 }
 
 // ArgType type
@@ -184,9 +235,10 @@ var ArgType = [...]string{
 	"gym",
 	"group",
 	"acmsguru",
+	"problemset",
 	"contest",
 	"gym",
-	"contest",
+	"problemset",
 	"group",
 	"acmsguru",
 	"acmsguru",
@@ -208,7 +260,7 @@ func parseArg(arg string) map[string]string {
 			}
 			if ArgType[k] != "" {
 				output["problemType"] = ArgType[k]
-				if k < 4 {
+				if k < argKeywordCount {
 					return output
 				}
 			}
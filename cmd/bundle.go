@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// bundleExts sources which can be bundled
+var bundleExts = map[string]bool{
+	".c": true, ".cc": true, ".cpp": true, ".cxx": true, ".c++": true,
+	".h": true, ".hh": true, ".hpp": true, ".hxx": true,
+}
+
+var (
+	includeReg = regexp.MustCompile(`^\s*#\s*include\s*"([^"]+)"`)
+	pragmaReg  = regexp.MustCompile(`^\s*#\s*pragma\s+once\b`)
+	guardReg   = regexp.MustCompile(`^\s*#\s*ifndef\s+(\w+)\s*\n\s*#\s*define\s+(\w+)`)
+	ifReg      = regexp.MustCompile(`^\s*#\s*if(n?def)?\b`)
+	ifZeroReg  = regexp.MustCompile(`^\s*#\s*if\s+0\s*$`)
+	elseReg    = regexp.MustCompile(`^\s*#\s*(else|elif)\b`)
+	endifReg   = regexp.MustCompile(`^\s*#\s*endif\b`)
+	commentReg = regexp.MustCompile(`(?s)^(\s|//[^\n]*\n|/\*.*?\*/)*`)
+)
+
+type bundler struct {
+	includePaths []string
+	once         map[string]bool
+	active       map[string]bool
+	count        int
+}
+
+// resolve find an included file from the directory of the includer, then
+// the include paths
+func (b *bundler) resolve(dir, name string) string {
+	for _, base := range append([]string{dir}, b.includePaths...) {
+		path := filepath.Join(base, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil {
+				return abs
+			}
+			return path
+		}
+	}
+	return ""
+}
+
+// includeOnce whether a header protects itself from being included twice
+func includeOnce(data []byte) bool {
+	head := commentReg.Find(data)
+	rest := data[len(head):]
+	if pragmaReg.Match(rest) {
+		return true
+	}
+	m := guardReg.FindSubmatch(rest)
+	return m != nil && bytes.Equal(m[1], m[2])
+}
+
+// matchEndif return the line of the "#endif" which closes the "#if" at line i.
+// Return -1 if the block has "#else" or "#elif"
+func matchEndif(lines []string, i int) int {
+	depth := 0
+	for j := i; j < len(lines); j++ {
+		if ifReg.MatchString(lines[j]) {
+			depth++
+		} else if elseReg.MatchString(lines[j]) && depth == 1 {
+			return -1
+		} else if endifReg.MatchString(lines[j]) {
+			if depth--; depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// stripIfZero remove "#if 0 ... #endif" blocks
+func stripIfZero(lines []string) []string {
+	ret := []string{}
+	for i := 0; i < len(lines); i++ {
+		if ifZeroReg.MatchString(lines[i]) {
+			if end := matchEndif(lines, i); end != -1 {
+				i = end
+				continue
+			}
+		}
+		ret = append(ret, lines[i])
+	}
+	return ret
+}
+
+func (b *bundler) expand(path string, out *bytes.Buffer) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	b.active[path] = true
+	defer delete(b.active, path)
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	for _, line := range stripIfZero(lines) {
+		if pragmaReg.MatchString(line) {
+			continue
+		}
+		m := includeReg.FindStringSubmatch(line)
+		if m == nil {
+			out.WriteString(line + "\n")
+			continue
+		}
+		header := b.resolve(filepath.Dir(path), m[1])
+		if header == "" {
+			color.Yellow("Cannot find %v. Keep the include", m[1])
+			out.WriteString(line + "\n")
+			continue
+		}
+		if b.once[header] || b.active[header] {
+			continue
+		}
+		headerData, err := ioutil.ReadFile(header)
+		if err != nil {
+			return err
+		}
+		if includeOnce(headerData) {
+			b.once[header] = true
+		}
+		b.count++
+		fmt.Fprintf(out, "// begin %v\n", m[1])
+		if err = b.expand(header, out); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "// end %v\n", m[1])
+	}
+	return nil
+}
+
+// bundle expand all local quoted includes of a C/C++ source recursively.
+// Return the number of inlined headers
+func bundle(filename string, includePaths []string) (source string, count int, err error) {
+	b := &bundler{includePaths: includePaths, once: map[string]bool{}, active: map[string]bool{}}
+	if abs, e := filepath.Abs(filename); e == nil {
+		filename = abs
+	}
+	var out bytes.Buffer
+	if err = b.expand(filename, &out); err != nil {
+		return
+	}
+	return out.String(), b.count, nil
+}
+
+// bundleSource bundle filename if it's a C/C++ source and save the result to
+// "bundle/" next to it. Return the source to submit
+func bundleSource(filename, source string, includePaths []string) (string, error) {
+	if !bundleExts[strings.ToLower(filepath.Ext(filename))] {
+		return source, nil
+	}
+	bundled, count, err := bundle(filename, includePaths)
+	if err != nil || count == 0 {
+		return source, err
+	}
+	savePath := filepath.Join(filepath.Dir(filename), "bundle", filepath.Base(filename))
+	if err = os.MkdirAll(filepath.Dir(savePath), os.ModePerm); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(savePath, []byte(bundled), 0644); err != nil {
+		return "", err
+	}
+	color.Green("Bundled %v header(s). See %v", count, savePath)
+	return bundled, nil
+}
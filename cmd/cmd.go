@@ -51,6 +51,30 @@ func Eval(opts docopt.Opts) error {
 		return Clone()
 	} else if Args.Upgrade {
 		return Upgrade()
+	} else if Args.Problemset {
+		return Problemset()
+	} else if Args.Queue {
+		return Queue()
+	} else if Args.History {
+		return History()
+	} else if Args.Hack {
+		return Hack()
+	} else if Args.Upsolve {
+		return Upsolve()
+	} else if Args.Virtual {
+		return Virtual()
+	} else if Args.ShowRating {
+		return Rating()
+	} else if Args.Status {
+		return Status()
+	} else if Args.Contests {
+		return Contests()
+	} else if Args.Standings {
+		return Standings()
+	} else if Args.Dash {
+		return Dash()
+	} else if Args.Langs {
+		return Langs()
 	}
 	return nil
 }
@@ -21,7 +21,10 @@ func Config() (err error) {
 	ansi.Println(`5) set host domain`)
 	ansi.Println(`6) set proxy`)
 	ansi.Println(`7) set folders' name`)
-	index := util.ChooseIndex(8)
+	ansi.Println(`8) run "cf test" before "cf submit"`)
+	ansi.Println(`9) set include paths for bundling C/C++ codes`)
+	ansi.Println(`10) set hooks and notification for verdicts`)
+	index := util.ChooseIndex(11)
 	if index == 0 {
 		return cln.ConfigLogin()
 	} else if index == 1 {
@@ -38,6 +41,12 @@ func Config() (err error) {
 		return cfg.SetProxy()
 	} else if index == 7 {
 		return cfg.SetFolderName()
+	} else if index == 8 {
+		return cfg.SetTestBeforeSubmit()
+	} else if index == 9 {
+		return cfg.SetIncludePaths()
+	} else if index == 10 {
+		return cfg.SetVerdictHooks()
 	}
 	return
 }
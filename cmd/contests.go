@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+)
+
+func isRunning(contest client.APIContest) bool {
+	return contest.Phase == "CODING" || contest.Phase == "PENDING_SYSTEM_TEST" || contest.Phase == "SYSTEM_TEST"
+}
+
+// Contests command
+func Contests() (err error) {
+	cln := client.Instance
+	contests, err := cln.ContestList(Args.Gym)
+	if err != nil {
+		return
+	}
+	if Args.ICS != "" {
+		if Args.Past || Args.Running {
+			return errors.New("Only upcoming contests could be exported by --ics")
+		}
+		// Export all of them regardless of --limit
+		events := []client.APIContest{}
+		for _, contest := range contests {
+			if contest.Phase == "BEFORE" {
+				events = append(events, contest)
+			}
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].StartTimeSeconds < events[j].StartTimeSeconds
+		})
+		ics := client.ContestsICS(config.Instance.Host, events)
+		if err = ioutil.WriteFile(Args.ICS, []byte(ics), 0644); err != nil {
+			return
+		}
+		color.Green("Exported %v upcoming contest(s) to %v", len(events), Args.ICS)
+		return
+	}
+
+	// Show all but the past ones by default
+	upcoming, running, past := Args.Upcoming, Args.Running, Args.Past
+	if !upcoming && !running && !past {
+		upcoming, running = true, true
+	}
+	shown := []client.APIContest{}
+	for _, contest := range contests {
+		if (upcoming && contest.Phase == "BEFORE") || (running && isRunning(contest)) ||
+			(past && contest.Phase == "FINISHED") {
+			shown = append(shown, contest)
+		}
+	}
+	// The upcoming ones come first, then the most recent ones
+	sort.SliceStable(shown, func(i, j int) bool {
+		a, b := shown[i], shown[j]
+		if (a.Phase == "BEFORE") != (b.Phase == "BEFORE") {
+			return a.Phase == "BEFORE"
+		}
+		if a.Phase == "BEFORE" {
+			return a.StartTimeSeconds < b.StartTimeSeconds
+		}
+		return a.StartTimeSeconds > b.StartTimeSeconds
+	})
+	if Args.Limit > 0 && len(shown) > Args.Limit {
+		shown = shown[:Args.Limit]
+	}
+	if len(shown) == 0 {
+		return errors.New("Cannot find any contest")
+	}
+
+	registrations := map[int]string{}
+	if !Args.Gym {
+		if registrations, err = cln.Registrations(); err != nil {
+			color.Yellow("Cannot fetch the registrations: %v", err.Error())
+			err = nil
+		}
+	}
+	rows := [][]string{}
+	now := time.Now()
+	for _, contest := range shown {
+		state := "finished"
+		if contest.Phase == "BEFORE" {
+			state = "in " + formatDuration(contest.Start().Sub(now))
+		} else if isRunning(contest) {
+			state = "running"
+		}
+		start := ""
+		if contest.StartTimeSeconds != 0 {
+			start = contest.Start().In(time.Local).Format("2006-01-02 15:04")
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(contest.ID),
+			contest.Name,
+			start,
+			formatDuration(contest.Duration()),
+			state,
+			registrations[contest.ID],
+		})
+	}
+	printTable([]string{"#", "name", "start", "length", "state", "registration"}, rows, func(i int) *color.Color {
+		if isRunning(shown[i]) {
+			return color.New(color.FgGreen)
+		} else if registrations[shown[i].ID] == "registered" {
+			return color.New(color.FgCyan)
+		}
+		return nil
+	})
+	return
+}
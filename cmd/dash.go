@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	ansi "github.com/k0kubun/go-ansi"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// dashboard state of "cf dash"
+type dashboard struct {
+	mu          sync.Mutex
+	info        client.Info
+	contest     *client.APIContest
+	problems    []client.StatisInfo
+	submissions []client.Submission
+	rank        int
+	selected    int
+	message     string
+	keys        chan []byte
+	wake        chan struct{}
+	width       int
+	height      int
+}
+
+const (
+	dashEnter = "\x1b[?1049h\x1b[?25l"
+	dashLeave = "\x1b[?25h\x1b[?1049l"
+)
+
+func dashSize() (int, int) {
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 80, 24
+	}
+	return width, height
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h >= 24 {
+		return fmt.Sprintf("%vd %02d:%02d:%02d", h/24, h%24, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
+
+// fit cut or pad the line to width
+func fit(line string, width int) string {
+	runes := []rune(line)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return line + strings.Repeat(" ", width-len(runes))
+}
+
+// currentRow the row of the current participation in rows of your own. It's
+// the virtual one while a virtual contest made by cf is running, otherwise
+// the official one. Return nil if there is none
+func currentRow(rows []client.APIRanklistRow, virtual bool) *client.APIRanklistRow {
+	types := []string{"CONTESTANT", "OUT_OF_COMPETITION"}
+	if virtual {
+		types = []string{"VIRTUAL"}
+	}
+	for _, t := range types {
+		for i := range rows {
+			if rows[i].Party.ParticipantType == t {
+				return &rows[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (d *dashboard) setMessage(message string) {
+	d.mu.Lock()
+	d.message = message
+	d.mu.Unlock()
+}
+
+// fetch keep the data up to date until ctx is done. Submissions are fetched
+// more often while some of them are being judged
+func (d *dashboard) fetch(ctx context.Context) {
+	cln := client.Instance
+	var lastSlow time.Time
+	for {
+		if time.Since(lastSlow) >= 30*time.Second {
+			lastSlow = time.Now()
+			if problems, err := cln.Statis(d.info); err == nil {
+				d.mu.Lock()
+				d.problems = problems
+				d.mu.Unlock()
+			} else {
+				d.setMessage(err.Error())
+			}
+			query := url.Values{"handles": {cln.Handle}, "showUnofficial": {"true"}}
+			if standings, err := cln.ContestStandings(d.info, query); err == nil {
+				virtual := cln.FindVirtual(d.info)
+				now := time.Now()
+				running := virtual != nil && !now.Before(virtual.Start) && now.Before(virtual.End)
+				d.mu.Lock()
+				d.contest = &standings.Contest
+				if row := currentRow(standings.Rows, running); row != nil {
+					d.rank = row.Rank
+				}
+				d.mu.Unlock()
+			}
+		}
+		wait := 15 * time.Second
+		if submissions, err := cln.Submissions(d.info, 10); err == nil {
+			d.mu.Lock()
+			d.submissions = submissions
+			d.mu.Unlock()
+			for _, sub := range submissions {
+				if !sub.State(d.info).End {
+					wait = 2 * time.Second
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// render draw the whole screen. Every line is padded to the width, so that
+// nothing is left when the terminal is resized
+func (d *dashboard) render() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	width, height := d.width, d.height
+	lines := []string{}
+	add := func(line string, c *color.Color) {
+		line = fit(line, width)
+		if c != nil {
+			line = c.Sprint(line)
+		}
+		lines = append(lines, line)
+	}
+
+	title, clock := d.info.Hint(), ""
+	if d.contest != nil {
+		title = d.contest.Name
+		start := time.Unix(d.contest.StartTimeSeconds, 0)
+		end := start.Add(time.Duration(d.contest.DurationSeconds) * time.Second)
+		if now := time.Now(); now.Before(start) {
+			clock = "starts in " + formatDuration(start.Sub(now))
+		} else if now.Before(end) {
+			clock = "ends in " + formatDuration(end.Sub(now))
+		} else {
+			clock = "finished"
+		}
+	}
+	if d.rank > 0 {
+		clock += fmt.Sprintf("  rank %v", d.rank)
+	}
+	head := " " + title
+	if pad := width - len([]rune(head)) - len(clock) - 1; pad > 0 {
+		head += strings.Repeat(" ", pad) + clock
+	} else {
+		head += "  " + clock
+	}
+	add(head, color.New(color.Bold))
+	add(strings.Repeat("-", width), nil)
+
+	add(fmt.Sprintf("   %-4v %-40v %8v", "#", "problem", "passed"), color.New(color.FgCyan))
+	for i, prob := range d.problems {
+		mark := "  "
+		if i == d.selected {
+			mark = "> "
+		}
+		var c *color.Color
+		if strings.Contains(prob.State, "accepted") {
+			c = color.New(color.FgGreen)
+		} else if strings.Contains(prob.State, "rejected") {
+			c = color.New(color.FgRed)
+		}
+		if i == d.selected {
+			if c == nil {
+				c = color.New()
+			}
+			c.Add(color.ReverseVideo)
+		}
+		add(fmt.Sprintf(" %v%-4v %-40v %8v", mark, prob.ID, fit(prob.Name, 40), prob.Passed), c)
+	}
+	add(strings.Repeat("-", width), nil)
+
+	add(fmt.Sprintf(" %-10v %-16v %-24v %-28v %8v %10v", "#", "when", "problem", "status", "time", "memory"), color.New(color.FgCyan))
+	// Leave room for the help line
+	room := height - len(lines) - 2
+	for i, sub := range d.submissions {
+		if i >= room {
+			break
+		}
+		state := sub.State(d.info)
+		var c *color.Color
+		if state.VerdictType == "OK" {
+			c = color.New(color.FgGreen)
+		} else if state.End {
+			c = color.New(color.FgRed)
+		}
+		add(fmt.Sprintf(" %-10v %-16v %-24v %-28v %8v %10v", state.ID, state.When, fit(state.Problem, 24),
+			fit(state.Verdict, 28), sub.ParseTime(), sub.ParseMemory()), c)
+	}
+	for len(lines) < height-1 {
+		add("", nil)
+	}
+	if len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+	help := " [j/k] select  [p] parse  [t] test  [s] submit  [o] open  [r] refresh  [q] quit"
+	if d.message != "" {
+		help += "   " + d.message
+	}
+	add(help, color.New(color.FgYellow))
+
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H")
+	buf.WriteString(strings.Join(lines, "\r\n"))
+	buf.WriteString("\x1b[J")
+	ansi.Print(buf.String())
+}
+
+// readKeys send what is typed to d.keys
+func (d *dashboard) readKeys() {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			close(d.keys)
+			return
+		}
+		d.keys <- append([]byte{}, buf[:n]...)
+	}
+}
+
+// run "cf" with args in dir outside the dashboard. What is typed is passed
+// to it, since d.readKeys keeps reading the standard input
+func (d *dashboard) run(dir string, args ...string) (err error) {
+	self, err := os.Executable()
+	if err != nil {
+		return
+	}
+	cmd := exec.Command(self, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	ansi.Print(dashLeave)
+	color.Cyan("cf %v", strings.Join(args, " "))
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case key, ok := <-d.keys:
+				if !ok {
+					stdin.Close()
+					return
+				}
+				stdin.Write(key)
+			case <-done:
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+	close(done)
+	return
+}
+
+// act run the command of the key on the selected problem
+func (d *dashboard) act(key byte, state *terminal.State) {
+	d.mu.Lock()
+	if d.selected >= len(d.problems) {
+		d.mu.Unlock()
+		return
+	}
+	info := d.info
+	info.ProblemID = strings.ToLower(d.problems[d.selected].ID)
+	d.mu.Unlock()
+
+	URL, err := info.ProblemURL(config.Instance.Host)
+	if err != nil {
+		d.setMessage(err.Error())
+		return
+	}
+	cwd, _ := os.Getwd()
+	path := info.Path()
+	if key != 'p' && key != 'o' {
+		if _, err := os.Stat(path); err != nil {
+			d.setMessage(fmt.Sprintf("Parse %v first", info.ProblemID))
+			return
+		}
+	}
+
+	fd := int(os.Stdin.Fd())
+	terminal.Restore(fd, state)
+	switch key {
+	case 'p':
+		err = d.run(cwd, "parse", URL)
+	case 't':
+		err = d.run(path, "test")
+	case 's':
+		err = d.run(path, "submit", URL)
+	case 'o':
+		err = d.run(cwd, "open", URL)
+	}
+	terminal.MakeRaw(fd)
+	if key != 'o' {
+		color.Cyan("Press any key to return")
+		<-d.keys
+	}
+	ansi.Print(dashEnter + "\x1b[2J")
+	if err != nil {
+		d.setMessage(err.Error())
+	} else {
+		d.setMessage("")
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Dash command
+func Dash() (err error) {
+	info := Args.Info
+	if info.ContestID == "" {
+		return errors.New("Please specify a contest")
+	}
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("cf dash needs a terminal")
+	}
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return
+	}
+	defer terminal.Restore(fd, state)
+	ansi.Print(dashEnter)
+	defer ansi.Print(dashLeave)
+
+	d := &dashboard{info: info, keys: make(chan []byte), wake: make(chan struct{}, 1)}
+	d.width, d.height = dashSize()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.fetch(ctx)
+	go d.readKeys()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	last := time.Time{}
+	for {
+		select {
+		case key, ok := <-d.keys:
+			if !ok {
+				return
+			}
+			switch string(key) {
+			case "q", "\x03":
+				return
+			case "j", "\x1b[B":
+				d.mu.Lock()
+				if d.selected+1 < len(d.problems) {
+					d.selected++
+				}
+				d.mu.Unlock()
+			case "k", "\x1b[A":
+				d.mu.Lock()
+				if d.selected > 0 {
+					d.selected--
+				}
+				d.mu.Unlock()
+			case "r":
+				select {
+				case d.wake <- struct{}{}:
+				default:
+				}
+			case "p", "t", "s", "o":
+				d.act(key[0], state)
+				d.width, d.height = dashSize()
+			}
+			d.render()
+		case <-ticker.C:
+			// Redraw on resize, or every second for the countdown
+			width, height := dashSize()
+			if width != d.width || height != d.height {
+				d.mu.Lock()
+				d.width, d.height = width, height
+				d.mu.Unlock()
+				ansi.Print("\x1b[2J")
+			} else if time.Since(last) < time.Second {
+				continue
+			}
+			last = time.Now()
+			d.render()
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ansi "github.com/k0kubun/go-ansi"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// stressTimeLimit time limit of every run of the generator, the target and
+// the checker
+const stressTimeLimit = 5 * time.Second
+
+// runCommand run command in dir with stdin. Return its stdout
+func runCommand(command, dir string, stdin []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), stressTimeLimit)
+	defer cancel()
+	cmds := splitCmd(command)
+	if len(cmds) == 0 {
+		return nil, errors.New("Empty command")
+	}
+	cmd := exec.CommandContext(ctx, cmds[0], cmds[1:]...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.New("Time limit exceeded")
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %v", err.Error(), msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// stress run the target against tests of the generator, until the checker
+// rejects its output. Return the failing test and its seed, empty if there
+// is none
+func stress(filename string, template config.CodeTemplate, tries int) (test string, seed int, err error) {
+	dir := filepath.Dir(filename)
+	filter := scriptFilter(filepath.Base(filename))
+	run := func(script string) error {
+		if s := filter(script); len(s) > 0 {
+			fmt.Println(s)
+			cmds := splitCmd(s)
+			cmd := exec.Command(cmds[0], cmds[1:]...)
+			cmd.Dir = dir
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+		return nil
+	}
+
+	script := filter(template.Script)
+	if len(script) == 0 {
+		return "", 0, errors.New("Invalid script command. Please check config file")
+	}
+	if err = run(template.BeforeScript); err != nil {
+		return
+	}
+	defer run(template.AfterScript)
+
+	inPath := filepath.Join(dir, "input.txt")
+	outPath := filepath.Join(dir, "output.txt")
+	for i := 1; i <= tries; i++ {
+		input, err := runCommand(fmt.Sprintf("%v %v", Args.Generator, i), "", nil)
+		if err != nil {
+			return "", 0, fmt.Errorf("Generator failed #%v ... %v", i, err.Error())
+		}
+		verdict := ""
+		output, err := runCommand(script, dir, input)
+		if err != nil {
+			verdict = "Runtime error: " + err.Error()
+		} else {
+			if err = ioutil.WriteFile(inPath, input, 0644); err != nil {
+				return "", 0, err
+			}
+			if err = ioutil.WriteFile(outPath, output, 0644); err != nil {
+				return "", 0, err
+			}
+			if _, err = runCommand(fmt.Sprintf("%v %v %v", Args.Checker, inPath, outPath), "", nil); err != nil {
+				verdict = "Wrong answer: " + err.Error()
+			}
+		}
+		if verdict != "" {
+			ansi.Printf("\n")
+			color.Red("Failed #%v ... %v", i, verdict)
+			return string(input), i, nil
+		}
+		ansi.Printf("\rPassed %v/%v", i, tries)
+	}
+	ansi.Printf("\n")
+	return
+}
+
+// generatorSource find the source of the generator command, which is sent
+// with the hack. It's either a file in the command, e.g. "python3 gen.py",
+// or the source of a binary, e.g. "gen.cpp" of "./gen"
+func generatorSource(command string, templates []config.CodeTemplate) (filename string, index int, err error) {
+	for _, arg := range splitCmd(command) {
+		candidates := []string{arg}
+		if filepath.Ext(arg) == "" {
+			candidates, _ = filepath.Glob(arg + ".*")
+		}
+		for _, name := range candidates {
+			if stat, e := os.Stat(name); e != nil || stat.IsDir() {
+				continue
+			}
+			if _, e := getCode(name, templates); e == nil {
+				return getOneCode(name, templates)
+			}
+		}
+	}
+	return "", 0, fmt.Errorf(`Cannot find the source of the generator "%v"`, command)
+}
+
+// Hack command
+func Hack() (err error) {
+	cfg := config.Instance
+	cln := client.Instance
+	info := Args.Info
+	info.SubmissionID = Args.Submission
+	if info.ContestID == "" {
+		return errors.New("Please specify a contest")
+	}
+
+	dir := filepath.Join("hack", info.SubmissionID)
+	pull := func() (err error) {
+		filename, err := cln.PullSubmission(info, filepath.Join(dir, info.SubmissionID))
+		if err == nil {
+			color.Green("Saved %v", filename)
+		}
+		return
+	}
+	if err = pull(); err != nil {
+		if err = loginAgain(cln, err); err == nil {
+			err = pull()
+		}
+	}
+	if err != nil {
+		if err.Error() != client.ErrorSkip {
+			return
+		}
+		err = nil
+	}
+
+	test := client.HackTest{}
+	if Args.Input != "" {
+		data, err := ioutil.ReadFile(Args.Input)
+		if err != nil {
+			return err
+		}
+		test.Input = string(data)
+	} else if Args.Generator != "" {
+		source, genIndex, err := generatorSource(Args.Generator, cfg.Template)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		test.Generator = string(data)
+		test.GeneratorLang = cfg.Template[genIndex].Lang
+
+		// The code may have been pulled before
+		matches, _ := filepath.Glob(filepath.Join(dir, info.SubmissionID+".*"))
+		if len(matches) == 0 {
+			return errors.New("Cannot find the code of the submission")
+		}
+		filename, index, err := getOneCode(matches[0], cfg.Template)
+		if err != nil {
+			return err
+		}
+		input, seed, err := stress(filename, cfg.Template[index], Args.Tries)
+		if err != nil {
+			return err
+		}
+		if input == "" {
+			return errors.New("Cannot find any failing test")
+		}
+		test.Input = input
+		test.GeneratorArgs = strconv.Itoa(seed)
+		path := filepath.Join(dir, "test.txt")
+		if err = ioutil.WriteFile(path, []byte(input), 0644); err == nil {
+			color.Green("Saved the failing test to %v", path)
+		}
+	} else {
+		return errors.New("Please specify a test by --input, or find one by --gen and --checker")
+	}
+
+	if test.Generator != "" {
+		color.Cyan("Send the generator with argument %v, which generates the test", test.GeneratorArgs)
+	}
+	color.Cyan("-----Test-----")
+	lines := strings.Split(strings.TrimRight(test.Input, "\n"), "\n")
+	if len(lines) > 20 {
+		lines = append(lines[:20], "...")
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	if !util.YesOrNo("Are you sure to hack it? (y/n)") {
+		return
+	}
+
+	hack := func() (err error) {
+		_, err = cln.Hack(info, test)
+		return
+	}
+	if err = hack(); err != nil {
+		if err = loginAgain(cln, err); err == nil {
+			err = hack()
+		}
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+)
+
+// parseDate parse "2006-01-02" in local time. Empty string means zero time
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return t, fmt.Errorf(`Invalid date "%v"`, s)
+	}
+	return t, nil
+}
+
+// contestType the type of the contest of a problem. A problem of the
+// problemset belongs to the contest with the same id
+func contestType(problemType string) string {
+	if problemType == "problemset" {
+		return "contest"
+	}
+	return problemType
+}
+
+// History command
+func History() (err error) {
+	info := Args.Info
+	since, err := parseDate(Args.Since)
+	if err != nil {
+		return
+	}
+	until, err := parseDate(Args.Until)
+	if err != nil {
+		return
+	}
+	records, err := client.Instance.LoadHistory()
+	if err != nil {
+		return
+	}
+
+	verdict := strings.ToLower(Args.Verdict)
+	rows := [][]string{}
+	shown := []client.HistoryRecord{}
+	for _, r := range records {
+		if info.ContestID != "" && (contestType(r.Info.ProblemType) != contestType(info.ProblemType) ||
+			r.Info.GroupID != info.GroupID || r.Info.ContestID != info.ContestID) {
+			continue
+		}
+		if info.ProblemID != "" && !strings.EqualFold(r.Info.ProblemID, info.ProblemID) {
+			continue
+		}
+		if verdict != "" && !strings.Contains(strings.ToLower(r.Verdict), verdict) {
+			continue
+		}
+		if r.Submitted.Before(since) || (!until.IsZero() && !r.Submitted.Before(until.AddDate(0, 0, 1))) {
+			continue
+		}
+		// Nothing is known but the verdict until it's judged
+		usedTime, memory, judge := "", "", ""
+		if !r.Judged.IsZero() {
+			usedTime = fmt.Sprintf("%v ms", r.Time)
+			memory = fmt.Sprintf("%v KB", r.Memory/1024)
+			judge = r.Judged.Sub(r.Submitted).Round(time.Second).String()
+		}
+		rows = append(rows, []string{
+			r.SubmissionID,
+			r.Submitted.In(time.Local).Format("2006-01-02 15:04"),
+			r.Info.Hint(),
+			client.Langs[r.LangID],
+			r.Verdict,
+			usedTime,
+			memory,
+			judge,
+		})
+		shown = append(shown, r)
+	}
+	printTable([]string{"#", "when", "problem", "lang", "verdict", "time", "memory", "judging"}, rows, func(i int) *color.Color {
+		v := shown[i].Verdict
+		if strings.HasPrefix(v, "Accepted") || strings.HasPrefix(v, "Pretests passed") {
+			return color.New(color.FgGreen)
+		} else if v != "" {
+			return color.New(color.FgRed)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+)
+
+// checkTemplateLangs warn about templates whose language no longer exists
+// on the submit page. The built-in languages may be older than the
+// templates, so only the fetched languages are checked
+func checkTemplateLangs(templates []config.CodeTemplate) {
+	langs := client.Instance.FetchedLangs()
+	if langs == nil {
+		return
+	}
+	for _, template := range templates {
+		if _, ok := langs[template.Lang]; !ok {
+			color.Yellow(`Template "%v" (%v) uses language %v which no longer exists. Please add it again by "cf config"`,
+				template.Alias, template.Path, template.Lang)
+		}
+	}
+}
+
+func sortedLangIDs(langs map[string]string) []string {
+	ids := []string{}
+	for id := range langs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		x, _ := strconv.Atoi(ids[i])
+		y, _ := strconv.Atoi(ids[j])
+		return x < y
+	})
+	return ids
+}
+
+// Langs command
+func Langs() (err error) {
+	cln := client.Instance
+	if Args.Update {
+		added, removed, err := cln.UpdateLangs()
+		if err != nil {
+			if err = loginAgain(cln, err); err == nil {
+				added, removed, err = cln.UpdateLangs()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		for _, id := range sortedLangIDs(added) {
+			color.Green("+ %v: %v", id, added[id])
+		}
+		for _, id := range sortedLangIDs(removed) {
+			color.Red("- %v: %v", id, removed[id])
+		}
+		color.Green("Updated %v languages", len(client.Langs))
+		checkTemplateLangs(config.Instance.Template)
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, id := range sortedLangIDs(client.Langs) {
+		ext, _ := client.LangExt(client.Langs[id])
+		rows = append(rows, []string{id, client.Langs[id], ext})
+	}
+	printTable([]string{"id", "lang", "ext"}, rows, nil)
+	return
+}
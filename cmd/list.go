@@ -3,7 +3,12 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"io"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -16,6 +21,11 @@ import (
 func List() (err error) {
 	cln := client.Instance
 	info := Args.Info
+	switch Args.Format {
+	case "", "table", "json", "csv", "tsv":
+	default:
+		return fmt.Errorf(`Unknown format "%v"`, Args.Format)
+	}
 	problems, err := cln.Statis(info)
 	if err != nil {
 		if err = loginAgain(cln, err); err == nil {
@@ -25,16 +35,12 @@ func List() (err error) {
 	if err != nil {
 		return
 	}
-	var buf bytes.Buffer
-	output := io.Writer(&buf)
-	table := tablewriter.NewWriter(output)
-	table.SetHeader([]string{"#", "problem", "passed", "limit", "IO"})
-	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-	table.SetAlignment(tablewriter.ALIGN_CENTER)
-	table.SetCenterSeparator("|")
-	table.SetAutoWrapText(false)
+	if Args.Format != "" && Args.Format != "table" {
+		return printStats(problems, Args.Format)
+	}
+	rows := [][]string{}
 	for _, prob := range problems {
-		table.Append([]string{
+		rows = append(rows, []string{
 			prob.ID,
 			prob.Name,
 			prob.Passed,
@@ -42,19 +48,87 @@ func List() (err error) {
 			prob.IO,
 		})
 	}
+	printTable([]string{"#", "problem", "passed", "limit", "IO"}, rows, func(i int) *color.Color {
+		if strings.Contains(problems[i].State, "accepted") {
+			return color.New(color.BgGreen)
+		} else if strings.Contains(problems[i].State, "rejected") {
+			return color.New(color.BgRed)
+		}
+		return nil
+	})
+	return
+}
+
+// printStats print structured statis of problems in the format of json, csv
+// or tsv
+func printStats(problems []client.StatisInfo, format string) error {
+	stats := []client.ProblemStats{}
+	for _, prob := range problems {
+		stats = append(stats, prob.Stats())
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	records := [][]string{{"id", "name", "passed", "accepted", "rejected", "untouched",
+		"time_limit_ms", "memory_limit_mb", "input", "output"}}
+	for _, s := range stats {
+		records = append(records, []string{
+			s.ID,
+			s.Name,
+			strconv.Itoa(s.Passed),
+			strconv.FormatBool(s.Accepted),
+			strconv.FormatBool(s.Rejected),
+			strconv.FormatBool(s.Untouched),
+			strconv.Itoa(s.TimeLimit),
+			strconv.Itoa(s.MemoryLimit),
+			s.Input,
+			s.Output,
+		})
+	}
+	switch format {
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(records)
+		return w.Error()
+	case "tsv":
+		for _, record := range records {
+			for i := range record {
+				record[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(record[i])
+			}
+			fmt.Println(strings.Join(record, "\t"))
+		}
+		return nil
+	}
+	return fmt.Errorf(`Unknown format "%v"`, format)
+}
+
+// printTable render rows as a table. paint returns the color of the i-th row, nil
+// means no color
+func printTable(header []string, rows [][]string, paint func(i int) *color.Color) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
 	table.Render()
 
 	scanner := bufio.NewScanner(io.Reader(&buf))
 	for i := -2; scanner.Scan(); i++ {
 		line := scanner.Text()
-		if i >= 0 {
-			if strings.Contains(problems[i].State, "accepted") {
-				line = color.New(color.BgGreen).Sprint(line)
-			} else if strings.Contains(problems[i].State, "rejected") {
-				line = color.New(color.BgRed).Sprint(line)
+		if i >= 0 && paint != nil {
+			if c := paint(i); c != nil {
+				line = c.Sprint(line)
 			}
 		}
 		ansi.Println(line)
 	}
-	return
 }
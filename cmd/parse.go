@@ -27,15 +27,12 @@ func Parse() (err error) {
 	}
 	work := func() error {
 		_, paths, err := cln.Parse(info)
-		if err != nil {
-			return err
-		}
 		if cfg.GenAfterParse {
 			for _, path := range paths {
 				gen(source, path, ext)
 			}
 		}
-		return nil
+		return err
 	}
 	if err = work(); err != nil {
 		if err = loginAgain(cln, err); err == nil {
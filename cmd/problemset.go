@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+	"github.com/xalanq/cf-tool/util"
+)
+
+// parseRange parse "l-r", "l", "l-" or "-r". An empty side is unbounded
+func parseRange(s string) (l, r int, err error) {
+	l, r = 0, int(^uint(0)>>1)
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return
+	}
+	atoi := func(s string) (int, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf(`Invalid range "%v"`, s)
+		}
+		return n, nil
+	}
+	p := strings.Index(s, "-")
+	if p == -1 {
+		l, err = atoi(s)
+		return l, l, err
+	}
+	if left := s[:p]; strings.TrimSpace(left) != "" {
+		if l, err = atoi(left); err != nil {
+			return
+		}
+	}
+	if right := s[p+1:]; strings.TrimSpace(right) != "" {
+		if r, err = atoi(right); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func splitList(s string) (tags []string) {
+	for _, tag := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return
+}
+
+// parseProblemInto fetch samples of a problem of a contest into the workspace
+func parseProblemInto(contestID int, problemID string) error {
+	info := Args.Info
+	info.ProblemType = "contest"
+	if contestID >= 100000 {
+		info.ProblemType = "gym"
+	}
+	info.GroupID = ""
+	info.ContestID = strconv.Itoa(contestID)
+	info.ProblemID = strings.ToLower(problemID)
+	info.RootPath = filepath.Join(filepath.Dir(info.RootPath), config.Instance.FolderName[info.ProblemType])
+	Args.Info = info
+	return Parse()
+}
+
+// chooseToParse ask for an index in [0, maxLen) to parse. Return -1 if skip
+func chooseToParse(maxLen int) int {
+	color.Cyan("Parse a problem (index), empty to skip: ")
+	for {
+		index := util.ScanlineTrim()
+		if index == "" {
+			return -1
+		}
+		i, err := strconv.Atoi(index)
+		if err == nil && i >= 0 && i < maxLen {
+			return i
+		}
+		color.Red("Invalid index! Please try again: ")
+	}
+}
+
+// Problemset command
+func Problemset() (err error) {
+	cln := client.Instance
+	minRating, maxRating, err := parseRange(Args.Rating)
+	if err != nil {
+		return
+	}
+	minSolved, maxSolved, err := parseRange(Args.Solved)
+	if err != nil {
+		return
+	}
+	if Args.Unsolved && Args.Handle == "" {
+		return errors.New("You have to configure your handle by `cf config`")
+	}
+
+	problems, err := cln.Problemset(splitList(Args.Tags))
+	if err != nil {
+		return
+	}
+	solved := map[string]bool{}
+	if Args.Handle != "" {
+		if solved, err = cln.SolvedProblems(Args.Handle); err != nil {
+			return
+		}
+	}
+
+	shown := []client.ProblemsetInfo{}
+	for _, prob := range problems {
+		if Args.Unsolved && solved[prob.ID()] {
+			continue
+		}
+		if (Args.Rating != "" && (prob.Rating < minRating || prob.Rating > maxRating)) ||
+			prob.SolvedCount < minSolved || prob.SolvedCount > maxSolved {
+			continue
+		}
+		shown = append(shown, prob)
+		if Args.Limit > 0 && len(shown) >= Args.Limit {
+			break
+		}
+	}
+	if len(shown) == 0 {
+		return errors.New("Cannot find any problem")
+	}
+
+	rows := [][]string{}
+	for i, prob := range shown {
+		rating := ""
+		if prob.Rating > 0 {
+			rating = strconv.Itoa(prob.Rating)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(i),
+			prob.ID(),
+			prob.Name,
+			rating,
+			strconv.Itoa(prob.SolvedCount),
+			strings.Join(prob.Tags, ", "),
+		})
+	}
+	printTable([]string{"#", "id", "problem", "rating", "solved", "tags"}, rows, func(i int) *color.Color {
+		if solved[shown[i].ID()] {
+			return color.New(color.BgGreen)
+		}
+		return nil
+	})
+
+	i := chooseToParse(len(shown))
+	if i < 0 {
+		return
+	}
+	return parseProblemInto(shown[i].ContestID, shown[i].Index)
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ansi "github.com/k0kubun/go-ansi"
+	"github.com/xalanq/cf-tool/client"
+)
+
+// parseAt parse the time of "--at". Return atStart if it's "start"
+func parseAt(s string) (at time.Time, atStart bool, err error) {
+	s = strings.TrimSpace(s)
+	now := time.Now()
+	if s == "start" {
+		return at, true, nil
+	}
+	if strings.HasPrefix(s, "+") {
+		d, err := time.ParseDuration(s[1:])
+		if err != nil {
+			return at, false, fmt.Errorf(`Invalid duration "%v"`, s)
+		}
+		return now.Add(d), false, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if at, err = time.ParseInLocation(layout, s, time.Local); err == nil {
+			return
+		}
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			at = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+			if at.Before(now) {
+				at = at.AddDate(0, 0, 1)
+			}
+			return at, false, nil
+		}
+	}
+	return at, false, fmt.Errorf(`Invalid time "%v"`, s)
+}
+
+// enqueue save a submission to the queue instead of submitting it
+func enqueue(info client.Info, langID, filename, source string) (err error) {
+	at, atStart, err := parseAt(Args.At)
+	if err != nil {
+		return
+	}
+	if info.ProblemID == "" {
+		return errors.New(client.ErrorNeedProblemID)
+	}
+	if _, err = info.SubmitURL(""); err != nil {
+		return
+	}
+	if abs, e := filepath.Abs(filename); e == nil {
+		filename = abs
+	}
+	q, err := client.Instance.LoadQueue()
+	if err != nil {
+		return
+	}
+	job, err := q.Add(client.QueueJob{
+		Info:     info,
+		LangID:   langID,
+		Filename: filename,
+		Source:   source,
+		AtStart:  atStart,
+		At:       at,
+	})
+	if err != nil {
+		return
+	}
+	color.Green("Queued #%v: %v at %v", job.ID, info.Hint(), job.When())
+	color.Cyan("Run `cf queue run` to submit it on time")
+	return
+}
+
+// contestClock the start of a contest and the offset of the server's clock,
+// both fetched by one countdown
+type contestClock struct {
+	start  time.Time
+	offset time.Duration
+	err    error
+}
+
+// clockOf the clock of the contest of info. It's fetched once per contest and
+// kept in clocks
+func clockOf(cln *client.Client, info client.Info, clocks map[string]*contestClock) *contestClock {
+	key := strings.Join([]string{info.ProblemType, info.GroupID, info.ContestID}, "/")
+	if clock, ok := clocks[key]; ok {
+		return clock
+	}
+	count, serverTime, err := cln.Countdown(info)
+	if err != nil {
+		if err = loginAgain(cln, err); err == nil {
+			count, serverTime, err = cln.Countdown(info)
+		}
+	}
+	now := time.Now()
+	clock := &contestClock{
+		start:  now.Add(time.Duration(count) * time.Second),
+		offset: serverTime.Sub(now),
+		err:    err,
+	}
+	clocks[key] = clock
+	return clock
+}
+
+// nextJob find the job which is due first on the server's clock. Return its
+// index and how long to wait, or -1 if there is none. Jobs whose countdown
+// fails are skipped and added to skipped
+func nextJob(cln *client.Client, jobs []client.QueueJob, clocks map[string]*contestClock, skipped map[int]bool) (index int, wait time.Duration) {
+	index = -1
+	for i, job := range jobs {
+		if skipped[job.ID] {
+			continue
+		}
+		clock := clockOf(cln, job.Info, clocks)
+		if clock.err != nil {
+			color.Red("Skip job #%v: %v", job.ID, clock.err.Error())
+			skipped[job.ID] = true
+			continue
+		}
+		w := time.Until(clock.start)
+		if !job.AtStart {
+			w = job.At.Sub(time.Now().Add(clock.offset))
+		}
+		if index == -1 || w < wait {
+			index, wait = i, w
+		}
+	}
+	return
+}
+
+// sameJobs whether a and b have the same jobs
+func sameJobs(a, b []client.QueueJob) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+// waitJob block until the job is due. Return false if the queue is changed
+// by cf in another terminal meanwhile, so the next job should be found again
+func waitJob(q *client.Queue, job client.QueueJob, wait time.Duration) bool {
+	if wait <= 0 {
+		return true
+	}
+	color.Green("Job #%v (%v) countdown: ", job.ID, job.Info.Hint())
+	jobs := q.Jobs
+	due := time.Now().Add(wait)
+	for left := time.Until(due); left > 0; left = time.Until(due) {
+		count := int(left.Seconds())
+		fmt.Printf("%02d:%02d:%02d\n", count/3600, count/60%60, count%60)
+		ansi.CursorUp(1)
+		if left > time.Second {
+			left = time.Second
+		}
+		time.Sleep(left)
+		if err := q.Reload(); err == nil && !sameJobs(jobs, q.Jobs) {
+			fmt.Println()
+			color.Yellow("The queue is changed")
+			return false
+		}
+	}
+	if job.AtStart {
+		time.Sleep(900 * time.Millisecond)
+	}
+	return true
+}
+
+// Queue command
+func Queue() (err error) {
+	cln := client.Instance
+	q, err := cln.LoadQueue()
+	if err != nil {
+		return
+	}
+	if Args.Cancel {
+		id, err := strconv.Atoi(Args.JobID)
+		if err != nil {
+			return fmt.Errorf(`Invalid job id "%v"`, Args.JobID)
+		}
+		if err = q.Remove(id); err != nil {
+			return err
+		}
+		color.Green("Canceled #%v", id)
+		return nil
+	}
+	if len(q.Jobs) == 0 {
+		color.Cyan("The queue is empty")
+		return
+	}
+	if Args.Ls {
+		rows := [][]string{}
+		for _, job := range q.Jobs {
+			rows = append(rows, []string{
+				strconv.Itoa(job.ID),
+				job.When(),
+				job.Info.Hint(),
+				client.Langs[job.LangID],
+				job.Filename,
+			})
+		}
+		printTable([]string{"#", "when", "problem", "lang", "file"}, rows, nil)
+		return
+	}
+
+	clocks := map[string]*contestClock{}
+	skipped := map[int]bool{}
+	for {
+		if err = q.Reload(); err != nil {
+			return
+		}
+		index, wait := nextJob(cln, q.Jobs, clocks, skipped)
+		if index < 0 {
+			break
+		}
+		job := q.Jobs[index]
+		if !waitJob(q, job, wait) {
+			continue
+		}
+		// The job may be canceled in another terminal
+		if err = q.Reload(); err != nil {
+			return
+		}
+		if !q.Has(job.ID) {
+			color.Yellow("Job #%v is canceled", job.ID)
+			continue
+		}
+		color.Cyan("Run job #%v", job.ID)
+		if _, err = cln.Submit(job.Info, job.LangID, job.Source); err != nil {
+			if err = loginAgain(cln, err); err == nil {
+				_, err = cln.Submit(job.Info, job.LangID, job.Source)
+			}
+		}
+		if err != nil {
+			color.Red("Job #%v failed and is left in the queue: %v", job.ID, err.Error())
+			skipped[job.ID] = true
+			continue
+		}
+		if err = q.Remove(job.ID); err != nil {
+			color.Red(err.Error())
+		}
+	}
+	if len(skipped) > 0 {
+		color.Yellow("%v job(s) are skipped or failed, and left in the queue", len(skipped))
+	}
+	return nil
+}
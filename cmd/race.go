@@ -9,7 +9,6 @@ import (
 
 // Race command
 func Race() (err error) {
-	cfg := config.Instance
 	cln := client.Instance
 	info := Args.Info
 	if err = cln.RaceContest(info); err != nil {
@@ -20,6 +19,12 @@ func Race() (err error) {
 	if err != nil {
 		return
 	}
+	return openContest(info)
+}
+
+// openContest open the pages of the contest and parse all problems
+func openContest(info client.Info) (err error) {
+	cfg := config.Instance
 	time.Sleep(1)
 	URL, err := info.ProblemSetURL(cfg.Host)
 	if err != nil {
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ansi "github.com/k0kubun/go-ansi"
+	"github.com/xalanq/cf-tool/client"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ratingBands lower bounds of the ranks of codeforces and their colors
+var ratingBands = []struct {
+	min   int
+	color *color.Color
+}{
+	{2400, color.New(color.FgRed)},
+	{2100, color.New(color.FgYellow)},
+	{1900, color.New(color.FgMagenta)},
+	{1600, color.New(color.FgBlue)},
+	{1400, color.New(color.FgCyan)},
+	{1200, color.New(color.FgGreen)},
+	{-1 << 31, color.New(color.FgWhite)},
+}
+
+func ratingColor(rating int) *color.Color {
+	for _, band := range ratingBands {
+		if rating >= band.min {
+			return band.color
+		}
+	}
+	return nil
+}
+
+// ratingChart draw a line chart of ratings. Every row is colored by the rank
+// of its rating
+func ratingChart(ratings []int, width, height int) []string {
+	low, high := ratings[0], ratings[0]
+	for _, r := range ratings {
+		if r < low {
+			low = r
+		}
+		if r > high {
+			high = r
+		}
+	}
+	low, high = low-50, high+50
+	row := func(r int) int {
+		return (high - r) * (height - 1) / (high - low)
+	}
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+	}
+	last := -1
+	for x := 0; x < width; x++ {
+		i := 0
+		if width > 1 {
+			i = x * (len(ratings) - 1) / (width - 1)
+		}
+		y := row(ratings[i])
+		if last != -1 {
+			for j := last; j != y; {
+				grid[j][x] = '│'
+				if j < y {
+					j++
+				} else {
+					j--
+				}
+			}
+		}
+		grid[y][x] = '●'
+		last = y
+	}
+	lines := []string{}
+	for i, cells := range grid {
+		r := high - i*(high-low)/(height-1)
+		line := fmt.Sprintf("%5v ┤", r) + string(cells)
+		lines = append(lines, ratingColor(r).Sprint(line))
+	}
+	return lines
+}
+
+// Rating command
+func Rating() (err error) {
+	cln := client.Instance
+	if Args.Changes {
+		return ratingChanges()
+	}
+	handle := Args.Handle
+	if handle == "" {
+		handle = cln.Handle
+	}
+	if handle == "" {
+		return errors.New("Please specify a handle")
+	}
+	user, err := cln.UserInfo(handle)
+	if err != nil {
+		return
+	}
+	changes, err := cln.UserRating(handle)
+	if err != nil {
+		return
+	}
+	ratingColor(user.Rating).Printf("%v: %v %v (max: %v %v)\n", user.Handle, user.Rank, user.Rating, user.MaxRank, user.MaxRating)
+	if len(changes) == 0 {
+		return errors.New("The user has not taken part in any rated contest")
+	}
+
+	ratings := []int{}
+	for _, c := range changes {
+		ratings = append(ratings, c.NewRating)
+	}
+	width, _, e := terminal.GetSize(int(os.Stdout.Fd()))
+	if e != nil || width < 20 {
+		width = 80
+	}
+	for _, line := range ratingChart(ratings, width-8, 15) {
+		ansi.Println(line)
+	}
+
+	rows := [][]string{}
+	start := 0
+	if Args.Limit > 0 && len(changes) > Args.Limit {
+		start = len(changes) - Args.Limit
+	}
+	shown := changes[start:]
+	for i := len(shown) - 1; i >= 0; i-- {
+		c := shown[i]
+		rows = append(rows, []string{
+			fmt.Sprint(c.ContestID),
+			c.ContestName,
+			time.Unix(c.RatingUpdateTimeSeconds, 0).In(time.Local).Format("2006-01-02"),
+			fmt.Sprint(c.Rank),
+			fmt.Sprintf("%v -> %v", c.OldRating, c.NewRating),
+			fmt.Sprintf("%+d", c.NewRating-c.OldRating),
+		})
+	}
+	printTable([]string{"#", "contest", "when", "rank", "rating", "delta"}, rows, func(i int) *color.Color {
+		return ratingColor(shown[len(shown)-1-i].NewRating)
+	})
+	return
+}
+
+// ratingChanges show the rating changes of a contest
+func ratingChanges() (err error) {
+	cln := client.Instance
+	changes, err := cln.RatingChanges(Args.Info)
+	if err != nil {
+		return
+	}
+	handles := map[string]bool{strings.ToLower(cln.Handle): true}
+	for _, h := range splitList(Args.Handles) {
+		handles[strings.ToLower(h)] = true
+	}
+	rows := [][]string{}
+	shown := []client.APIRatingChange{}
+	for _, c := range changes {
+		if Args.Handles != "" && !handles[strings.ToLower(c.Handle)] {
+			continue
+		}
+		if Args.Limit > 0 && len(shown) >= Args.Limit && !handles[strings.ToLower(c.Handle)] {
+			continue
+		}
+		shown = append(shown, c)
+		rows = append(rows, []string{
+			fmt.Sprint(c.Rank),
+			c.Handle,
+			fmt.Sprintf("%v -> %v", c.OldRating, c.NewRating),
+			fmt.Sprintf("%+d", c.NewRating-c.OldRating),
+		})
+	}
+	if len(rows) == 0 {
+		return errors.New("Cannot find any rating change. Maybe the system testing is not finished")
+	}
+	color.Cyan("%v", changes[0].ContestName)
+	printTable([]string{"rank", "who", "rating", "delta"}, rows, func(i int) *color.Color {
+		if strings.EqualFold(shown[i].Handle, cln.Handle) {
+			return color.New(color.BgBlue)
+		}
+		return ratingColor(shown[i].NewRating)
+	})
+	return
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+)
+
+// formatPenaltyTime time since the start of the contest, e.g. "1:05"
+func formatPenaltyTime(seconds int64) string {
+	return fmt.Sprintf("%v:%02d", seconds/3600, seconds/60%60)
+}
+
+// attemptsMark attempts of a problem as the site shows, "+" or "+2" if
+// solved after rejected attempts, "-3" if not solved yet
+func attemptsMark(r client.APIProblemResult) string {
+	if r.Points > 0 {
+		if r.RejectedAttemptCount > 0 {
+			return "+" + strconv.Itoa(r.RejectedAttemptCount)
+		}
+		return "+"
+	}
+	if r.RejectedAttemptCount > 0 {
+		return "-" + strconv.Itoa(r.RejectedAttemptCount)
+	}
+	return ""
+}
+
+// standingsCell a problem result in the style of the contest type
+func standingsCell(contestType string, r client.APIProblemResult) string {
+	mark := attemptsMark(r)
+	if r.Points <= 0 {
+		return mark
+	}
+	switch contestType {
+	case "ICPC":
+		return fmt.Sprintf("%v %v", mark, formatPenaltyTime(r.BestSubmissionTimeSeconds))
+	case "IOI":
+		return fmt.Sprint(r.Points)
+	}
+	if r.RejectedAttemptCount > 0 {
+		return fmt.Sprintf("%v %v %v", r.Points, mark, formatPenaltyTime(r.BestSubmissionTimeSeconds))
+	}
+	return fmt.Sprintf("%v %v", r.Points, formatPenaltyTime(r.BestSubmissionTimeSeconds))
+}
+
+// Standings command
+func Standings() (err error) {
+	cln := client.Instance
+	info := Args.Info
+	page := Args.Page
+	if page <= 0 {
+		return errors.New("The page should be positive")
+	}
+
+	query := url.Values{}
+	handles := splitList(Args.Handles)
+	if Args.Friends {
+		friends, err := cln.Friends()
+		if err != nil {
+			if err = loginAgain(cln, err); err == nil {
+				friends, err = cln.Friends()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		handles = append([]string{cln.Handle}, friends...)
+	}
+	if len(handles) > 0 {
+		query.Set("handles", strings.Join(handles, ";"))
+	}
+	if Args.Room != "" {
+		query.Set("room", Args.Room)
+	}
+	if Args.Limit > 0 {
+		query.Set("from", strconv.Itoa((page-1)*Args.Limit+1))
+		query.Set("count", strconv.Itoa(Args.Limit))
+	}
+	standings, err := cln.ContestStandings(info, query)
+	if err != nil {
+		return
+	}
+	rows := standings.Rows
+
+	// Always show your own row, even if it's on another page, unless it's
+	// filtered out by the room
+	mine := false
+	for _, row := range rows {
+		mine = mine || row.Party.Has(cln.Handle)
+	}
+	if !mine && len(handles) == 0 && cln.Handle != "" {
+		myQuery := url.Values{"handles": {cln.Handle}}
+		if Args.Room != "" {
+			myQuery.Set("room", Args.Room)
+		}
+		if my, err := cln.ContestStandings(info, myQuery); err == nil {
+			rows = append(my.Rows, rows...)
+		}
+	}
+	if len(rows) == 0 {
+		return errors.New("Cannot find any row of the standings")
+	}
+
+	contest := standings.Contest
+	color.Cyan("%v (%v), page %v", contest.Name, contest.Phase, page)
+	header := []string{"#", "who", "score"}
+	if contest.Type == "ICPC" {
+		header = append(header, "penalty")
+	} else if contest.Type == "CF" {
+		header = append(header, "hacks")
+	}
+	for _, prob := range standings.Problems {
+		header = append(header, prob.Index)
+	}
+	table := [][]string{}
+	for _, row := range rows {
+		line := []string{strconv.Itoa(row.Rank), row.Party.Name(), fmt.Sprint(row.Points)}
+		if contest.Type == "ICPC" {
+			line = append(line, strconv.Itoa(row.Penalty))
+		} else if contest.Type == "CF" {
+			line = append(line, fmt.Sprintf("+%v:-%v", row.SuccessfulHackCount, row.UnsuccessfulHackCount))
+		}
+		for _, r := range row.ProblemResults {
+			line = append(line, standingsCell(contest.Type, r))
+		}
+		table = append(table, line)
+	}
+	printTable(header, table, func(i int) *color.Color {
+		if rows[i].Party.Has(cln.Handle) {
+			return color.New(color.BgBlue)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/config"
+)
+
+// testResultName the file of the last result of "cf test" in the folder of
+// a problem
+const testResultName = ".test.json"
+
+type testResult struct {
+	File   string    `json:"file"`
+	Passed int       `json:"passed"`
+	Total  int       `json:"total"`
+	Time   time.Time `json:"time"`
+}
+
+func saveTestResult(dir string, result testResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, testResultName), data, 0644)
+}
+
+func loadTestResult(dir string) *testResult {
+	data, err := ioutil.ReadFile(filepath.Join(dir, testResultName))
+	if err != nil {
+		return nil
+	}
+	var result testResult
+	if json.Unmarshal(data, &result) != nil {
+		return nil
+	}
+	return &result
+}
+
+// localFiles the number of samples and the sources matching some template in dir
+func localFiles(dir string, templates []config.CodeTemplate) (samples int, sources []string) {
+	suffix := map[string]bool{}
+	for _, temp := range templates {
+		for _, s := range temp.Suffix {
+			suffix["."+s] = true
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	reg := regexp.MustCompile(`^in(\d+).txt$`)
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() {
+			continue
+		}
+		if tmp := reg.FindStringSubmatch(name); tmp != nil {
+			if _, err := os.Stat(filepath.Join(dir, fmt.Sprintf("ans%v.txt", tmp[1]))); err == nil {
+				samples++
+			}
+		} else if suffix[filepath.Ext(name)] {
+			sources = append(sources, name)
+		}
+	}
+	return
+}
+
+// Status command
+func Status() (err error) {
+	cln := client.Instance
+	cfg := config.Instance
+	info := Args.Info
+	problems, err := cln.Statis(info)
+	if err != nil {
+		if err = loginAgain(cln, err); err == nil {
+			problems, err = cln.Statis(info)
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	rows := [][]string{}
+	for _, prob := range problems {
+		info.ProblemID = strings.ToLower(prob.ID)
+		dir := info.Path()
+		parsed, source, test, verdict := "-", "-", "-", "-"
+		samples, sources := localFiles(dir, cfg.Template)
+		if samples > 0 {
+			parsed = fmt.Sprintf("%v samples", samples)
+		}
+		if len(sources) > 0 {
+			source = strings.Join(sources, ", ")
+		}
+		if result := loadTestResult(dir); result != nil {
+			test = fmt.Sprintf("%v/%v %v", result.Passed, result.Total, result.File)
+		}
+		if record := cln.LastSubmitted(info); record != nil && record.Verdict != "" {
+			verdict = record.Verdict
+		} else if strings.Contains(prob.State, "accepted") {
+			verdict = "Accepted"
+		} else if strings.Contains(prob.State, "rejected") {
+			verdict = "Rejected"
+		}
+		rows = append(rows, []string{prob.ID, prob.Name, parsed, source, test, verdict})
+	}
+	printTable([]string{"#", "problem", "parsed", "source", "test", "verdict"}, rows, func(i int) *color.Color {
+		if strings.Contains(problems[i].State, "accepted") {
+			return color.New(color.BgGreen)
+		} else if strings.Contains(problems[i].State, "rejected") {
+			return color.New(color.BgRed)
+		}
+		return nil
+	})
+	return
+}
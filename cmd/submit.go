@@ -1,12 +1,42 @@
 package cmd
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/xalanq/cf-tool/client"
 	"github.com/xalanq/cf-tool/config"
+	"github.com/xalanq/cf-tool/util"
 )
 
+// testBeforeSubmit run all samples of current path. Return an error if any
+// sample failed and force is false
+func testBeforeSubmit(filename string, template config.CodeTemplate, force bool) error {
+	samples := getSampleID()
+	if len(samples) == 0 {
+		color.Yellow("Cannot find any sample file. Skip testing")
+		return nil
+	}
+	color.Cyan("Test %v before submitting", filename)
+	failed, err := runTests(filename, template, samples)
+	if err != nil {
+		return err
+	}
+	if len(failed) == 0 {
+		color.Green("Passed %v/%v samples", len(samples), len(samples))
+		return nil
+	}
+	color.Red("Passed %v/%v samples. Failed #%v", len(samples)-len(failed), len(samples), strings.Join(failed, ", #"))
+	if !force {
+		return fmt.Errorf("Refuse to submit. Use `cf submit --force` to submit anyway")
+	}
+	color.Yellow("Submit anyway")
+	return nil
+}
+
 // Submit command
 func Submit() (err error) {
 	cln := client.Instance
@@ -17,17 +47,49 @@ func Submit() (err error) {
 		return
 	}
 
+	checkTemplateLangs(cfg.Template[index : index+1])
+
+	if err = checkVirtual(info, Args.Late); err != nil {
+		return
+	}
+
+	if cfg.TestBeforeSubmit || Args.TestFirst {
+		if err = testBeforeSubmit(filename, cfg.Template[index], Args.Force); err != nil {
+			return
+		}
+	}
+
 	bytes, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return
 	}
 	source := string(bytes)
+	if source, err = bundleSource(filename, source, cfg.IncludePaths); err != nil {
+		return
+	}
+
+	if dup := cln.FindSubmitted(info, source); dup != nil {
+		color.Yellow("You have submitted the same code to %v at %v", info.Hint(), dup.When.In(time.Local).Format("2006-01-02 15:04"))
+		if dup.SubmissionID != "" {
+			color.Yellow("Submission %v: %v", dup.SubmissionID, dup.Verdict)
+		}
+		if !util.YesOrNo("Submit it again (y/n)? ") {
+			return
+		}
+	}
 
 	lang := cfg.Template[index].Lang
-	if err = cln.Submit(info, lang, source); err != nil {
+	if Args.At != "" {
+		return enqueue(info, lang, filename, source)
+	}
+	submission, err := cln.Submit(info, lang, source)
+	if err != nil {
 		if err = loginAgain(cln, err); err == nil {
-			err = cln.Submit(info, lang, source)
+			submission, err = cln.Submit(info, lang, source)
 		}
 	}
+	if err == nil {
+		reportFailure(info, submission)
+	}
 	return
 }
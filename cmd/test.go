@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/fatih/color"
@@ -61,13 +62,15 @@ func plain(raw []byte) string {
 	return b.String()
 }
 
-func judge(sampleID, command string) error {
+// judge run command with sample sampleID. Return whether the output is the same as the answer
+func judge(sampleID, command string) (bool, error) {
 	inPath := fmt.Sprintf("in%v.txt", sampleID)
 	ansPath := fmt.Sprintf("ans%v.txt", sampleID)
 	input, err := os.Open(inPath)
 	if err != nil {
-		return err
+		return false, err
 	}
+	defer input.Close()
 	var o bytes.Buffer
 	output := io.Writer(&o)
 
@@ -78,7 +81,7 @@ func judge(sampleID, command string) error {
 	cmd.Stdout = output
 	cmd.Stderr = os.Stderr
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("Runtime Error #%v ... %v", sampleID, err.Error())
+		return false, fmt.Errorf("Runtime Error #%v ... %v", sampleID, err.Error())
 	}
 
 	pid := int32(cmd.Process.Pid)
@@ -92,7 +95,7 @@ func judge(sampleID, command string) error {
 		select {
 		case err := <-ch:
 			if err != nil {
-				return fmt.Errorf("Runtime Error #%v ... %v", sampleID, err.Error())
+				return false, fmt.Errorf("Runtime Error #%v ... %v", sampleID, err.Error())
 			}
 			running = false
 		default:
@@ -120,7 +123,7 @@ func judge(sampleID, command string) error {
 	} else {
 		input, err := ioutil.ReadFile(inPath)
 		if err != nil {
-			return err
+			return false, err
 		}
 		state = color.New(color.FgRed).Sprintf("Failed #%v", sampleID)
 		dmp := diffmatchpatch.New()
@@ -145,36 +148,30 @@ func judge(sampleID, command string) error {
 	}
 
 	ansi.Printf("%v ... %.3fs %v\n%v", state, cmd.ProcessState.UserTime().Seconds(), parseMemory(maxMemory), diff)
-	return nil
+	return out == ans, nil
 }
 
-// Test command
-func Test() (err error) {
-	cfg := config.Instance
-	if len(cfg.Template) == 0 {
-		return errors.New("You have to add at least one code template by `cf config`")
-	}
-	samples := getSampleID()
-	if len(samples) == 0 {
-		return errors.New("Cannot find any sample file")
-	}
-	filename, index, err := getOneCode(Args.File, cfg.Template)
-	if err != nil {
-		return
-	}
-	template := cfg.Template[index]
+// scriptFilter replace the placeholders of scripts of templates with the
+// ones of filename
+func scriptFilter(filename string) func(string) string {
 	path, full := filepath.Split(filename)
 	ext := filepath.Ext(filename)
 	file := full[:len(full)-len(ext)]
 	rand := util.RandString(8)
 
-	filter := func(cmd string) string {
+	return func(cmd string) string {
 		cmd = strings.ReplaceAll(cmd, "$%rand%$", rand)
 		cmd = strings.ReplaceAll(cmd, "$%path%$", path)
 		cmd = strings.ReplaceAll(cmd, "$%full%$", full)
 		cmd = strings.ReplaceAll(cmd, "$%file%$", file)
 		return cmd
 	}
+}
+
+// runTests run the scripts of template with filename and judge all samples.
+// Return the samples which did not pass
+func runTests(filename string, template config.CodeTemplate, samples []string) (failed []string, err error) {
+	filter := scriptFilter(filename)
 
 	run := func(script string) error {
 		if s := filter(script); len(s) > 0 {
@@ -193,13 +190,44 @@ func Test() (err error) {
 	}
 	if s := filter(template.Script); len(s) > 0 {
 		for _, i := range samples {
-			err := judge(i, s)
+			passed, err := judge(i, s)
 			if err != nil {
 				color.Red(err.Error())
 			}
+			if !passed {
+				failed = append(failed, i)
+			}
 		}
 	} else {
-		return errors.New("Invalid script command. Please check config file")
+		return nil, errors.New("Invalid script command. Please check config file")
+	}
+	// The samples are read from the current directory, so the result belongs
+	// to it even if the code is in another one
+	file := filepath.Base(filename)
+	if rel, e := filepath.Rel(".", filename); e == nil {
+		file = rel
+	}
+	if e := saveTestResult(".", testResult{file, len(samples) - len(failed), len(samples), time.Now()}); e != nil {
+		color.Red("Cannot save the result: %v", e.Error())
 	}
-	return run(template.AfterScript)
+	err = run(template.AfterScript)
+	return
+}
+
+// Test command
+func Test() (err error) {
+	cfg := config.Instance
+	if len(cfg.Template) == 0 {
+		return errors.New("You have to add at least one code template by `cf config`")
+	}
+	samples := getSampleID()
+	if len(samples) == 0 {
+		return errors.New("Cannot find any sample file")
+	}
+	filename, index, err := getOneCode(Args.File, cfg.Template)
+	if err != nil {
+		return
+	}
+	_, err = runTests(filename, cfg.Template[index], samples)
+	return
 }
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+)
+
+// Upsolve command
+func Upsolve() (err error) {
+	cln := client.Instance
+	if cln.Handle == "" {
+		return errors.New("You have to configure your handle by `cf config`")
+	}
+	problems, err := cln.Upsolve(cln.Handle)
+	if err != nil {
+		return
+	}
+	if len(problems) == 0 {
+		return errors.New("Nothing to upsolve")
+	}
+	if Args.Limit > 0 && len(problems) > Args.Limit {
+		problems = problems[:Args.Limit]
+	}
+
+	rows := [][]string{}
+	for i, prob := range problems {
+		rating := ""
+		if prob.Rating > 0 {
+			rating = strconv.Itoa(prob.Rating)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(i),
+			prob.ID(),
+			prob.Name,
+			rating,
+			strconv.Itoa(prob.SolvedCount),
+			prob.ContestName,
+		})
+	}
+	printTable([]string{"#", "id", "problem", "rating", "solved", "contest"}, rows, func(i int) *color.Color {
+		if problems[i].Rating > 0 {
+			return ratingColor(problems[i].Rating)
+		}
+		return nil
+	})
+
+	i := chooseToParse(len(problems))
+	if i < 0 {
+		return
+	}
+	return parseProblemInto(problems[i].ContestID, problems[i].Index)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/xalanq/cf-tool/client"
+)
+
+// checkVirtual print the time left of the virtual contest of info. Return an
+// error if it has just ended and late is false. Long after the end, it's
+// upsolving and nothing is checked
+func checkVirtual(info client.Info, late bool) error {
+	virtual := client.Instance.FindVirtual(info)
+	if virtual == nil {
+		return nil
+	}
+	now := time.Now()
+	if now.Before(virtual.Start) {
+		color.Yellow("The virtual contest starts in %v", formatDuration(virtual.Start.Sub(now)))
+	} else if now.Before(virtual.End) {
+		color.Cyan("The virtual contest ends in %v", formatDuration(virtual.End.Sub(now)))
+	} else if virtual.JustEnded(now) && !late {
+		return errors.New("The virtual contest has ended. Use `cf submit --late` to submit anyway")
+	}
+	return nil
+}
+
+// Virtual command
+func Virtual() (err error) {
+	cln := client.Instance
+	info := Args.Info
+	if info.ContestID == "" {
+		return errors.New("Please specify a contest")
+	}
+	virtual := cln.FindVirtual(info)
+	if virtual == nil || !time.Now().Before(virtual.End) {
+		// Start at the next minute by default
+		start := time.Now().Truncate(time.Minute).Add(time.Minute)
+		if Args.At != "" {
+			var atStart bool
+			if start, atStart, err = parseAt(Args.At); err != nil {
+				return
+			}
+			if atStart {
+				return errors.New(`A virtual contest cannot start at "start"`)
+			}
+		}
+		register := func() (err error) {
+			registered, err := cln.RegisterVirtual(info, start)
+			virtual = &registered
+			return
+		}
+		if err = register(); err != nil {
+			if err = loginAgain(cln, err); err == nil {
+				err = register()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+	checkVirtual(info, true)
+	cln.RaceVirtual(*virtual)
+	return openContest(info)
+}
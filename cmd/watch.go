@@ -1,9 +1,46 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
 	"github.com/xalanq/cf-tool/client"
+	"github.com/xalanq/cf-tool/util"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
+// reportFailure show why the judged submission failed. Offer to save the
+// failed test as a sample if it's asked in a terminal. Nothing is shown
+// while the tests are not public, e.g. during the contest
+func reportFailure(info client.Info, submission client.Submission) {
+	test, err := client.Instance.ShowFailure(info, submission)
+	if err != nil {
+		if err.Error() != client.ErrorTestsNotPublic {
+			color.Yellow("Cannot show the details of #%v: %v", submission.ParseID(), err.Error())
+		}
+		return
+	}
+	if test == nil || !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return
+	}
+	if test.Truncated {
+		color.Yellow("The test is too large to be saved as a sample")
+		return
+	}
+	info.ProblemID = submission.ParseProblemIndex()
+	path := info.Path()
+	if !util.YesOrNo(fmt.Sprintf("Save it as a sample in %v (y/n)? ", path)) {
+		return
+	}
+	index, err := test.Save(path)
+	if err != nil {
+		color.Red(err.Error())
+		return
+	}
+	color.Green("Saved in%v.txt and ans%v.txt", index, index)
+}
+
 // Watch command
 func Watch() (err error) {
 	cln := client.Instance
@@ -12,9 +49,36 @@ func Watch() (err error) {
 	if Args.All {
 		n = -1
 	}
-	if _, err = cln.WatchSubmission(info, n, false); err != nil {
+	handles := splitList(Args.Handles)
+	watch := func() error {
+		if Args.Friends && len(handles) == 0 {
+			friends, err := cln.Friends()
+			if err != nil {
+				return err
+			}
+			handles = append([]string{cln.Handle}, friends...)
+		}
+		// Redrawing the table by moving the cursor corrupts logs and pipes
+		if Args.JSON || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+			_, err := cln.WatchSubmissionJSON(info, handles, n, os.Stdout)
+			return err
+		}
+		submissions, err := cln.WatchHandles(info, handles, n, false)
+		if err != nil || len(handles) > 0 {
+			return err
+		}
+		// Show why the latest submission failed
+		for _, sub := range submissions {
+			if info.ProblemID == "" || sub.ParseProblemIndex() == info.ProblemID {
+				reportFailure(info, sub)
+				break
+			}
+		}
+		return nil
+	}
+	if err = watch(); err != nil {
 		if err = loginAgain(cln, err); err == nil {
-			_, err = cln.WatchSubmission(info, n, false)
+			err = watch()
 		}
 	}
 	return
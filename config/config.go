@@ -24,13 +24,17 @@ type CodeTemplate struct {
 
 // Config load and save configuration
 type Config struct {
-	Template      []CodeTemplate    `json:"template"`
-	Default       int               `json:"default"`
-	GenAfterParse bool              `json:"gen_after_parse"`
-	Host          string            `json:"host"`
-	Proxy         string            `json:"proxy"`
-	FolderName    map[string]string `json:"folder_name"`
-	path          string
+	Template         []CodeTemplate    `json:"template"`
+	Default          int               `json:"default"`
+	GenAfterParse    bool              `json:"gen_after_parse"`
+	TestBeforeSubmit bool              `json:"test_before_submit"`
+	Host             string            `json:"host"`
+	Proxy            string            `json:"proxy"`
+	FolderName       map[string]string `json:"folder_name"`
+	IncludePaths     []string          `json:"include_paths"`
+	VerdictHooks     []string          `json:"verdict_hooks"`
+	Notify           bool              `json:"notify"`
+	path             string
 }
 
 // Instance global configuration
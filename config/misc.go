@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"regexp"
+	"strings"
 
 	"github.com/fatih/color"
+	homedir "github.com/mitchellh/go-homedir"
 	"github.com/xalanq/cf-tool/client"
 	"github.com/xalanq/cf-tool/util"
 )
@@ -15,6 +18,60 @@ func (c *Config) SetGenAfterParse() (err error) {
 	return c.save()
 }
 
+// SetTestBeforeSubmit set it yes or no
+func (c *Config) SetTestBeforeSubmit() (err error) {
+	c.TestBeforeSubmit = util.YesOrNo(`Run "cf test" before "cf submit" (y/n)? `)
+	return c.save()
+}
+
+// SetIncludePaths set paths to find headers when bundling C/C++ codes
+func (c *Config) SetIncludePaths() (err error) {
+	color.Cyan(`Set include paths for bundling C/C++ codes before submitting`)
+	color.Cyan(`Local headers (#include "...") are searched in the code's folder, then these paths`)
+	if len(c.IncludePaths) > 0 {
+		color.Green("Current include paths: %v", strings.Join(c.IncludePaths, ", "))
+	}
+	color.Cyan(`Enter one absolute path per line (e.g. "~/lib"), empty line to finish:`)
+	paths := []string{}
+	for {
+		path := util.ScanlineTrim()
+		if path == "" {
+			break
+		}
+		if path, err = homedir.Expand(path); err == nil {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				paths = append(paths, path)
+				continue
+			}
+		}
+		color.Red("%v is not a folder. Please input again: ", path)
+	}
+	c.IncludePaths = paths
+	return c.save()
+}
+
+// SetVerdictHooks set commands to run when a submission is judged
+func (c *Config) SetVerdictHooks() (err error) {
+	color.Cyan(`Set commands to run when a submission gets its final verdict`)
+	color.Cyan(`The verdict is passed by environment variables (CF_SUBMISSION_ID, CF_PROBLEM,`)
+	color.Cyan(`CF_VERDICT, CF_LANG, CF_TIME, CF_MEMORY, etc.) and as JSON on stdin`)
+	for _, hook := range c.VerdictHooks {
+		color.Green("Current hook: %v", hook)
+	}
+	color.Cyan(`Enter one command per line (e.g. "paplay ~/done.wav"), empty line to finish:`)
+	hooks := []string{}
+	for {
+		hook := util.ScanlineTrim()
+		if hook == "" {
+			break
+		}
+		hooks = append(hooks, hook)
+	}
+	c.VerdictHooks = hooks
+	c.Notify = util.YesOrNo(`Show a desktop notification when a submission is judged (y/n)? `)
+	return c.save()
+}
+
 func formatHost(host string) (string, error) {
 	reg := regexp.MustCompile(`https?://[\w\-]+(\.[\w\-]+)+/?`)
 	if !reg.MatchString(host) {